@@ -0,0 +1,38 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type DeviceEndpoint struct {
+	// URL to contact the device under.
+	URL string `json:"url"`
+}
+
+// MQTTEndpoint describes how to reach a device via a MQTT broker.
+type MQTTEndpoint struct {
+	// Secret in the same namespace containing the broker connection details.
+	// Keys: "url" (e.g. tcp://mosquitto:1883), "username" and "password".
+	BrokerSecret corev1.LocalObjectReference `json:"brokerSecret"`
+	// Base topic of the device,
+	// e.g. "shellies/shellyswitch25-C45BBE" or the Tasmota topic "tasmota_C45BBE".
+	Topic string `json:"topic"`
+}
+
+// DeviceInfo reports hardware and firmware details of a device.
+type DeviceInfo struct {
+	// Model or device type.
+	Model string `json:"model,omitempty"`
+	// Installed firmware version.
+	Firmware string `json:"firmware,omitempty"`
+	// Firmware version available for update, empty if up-to-date.
+	AvailableFirmware string `json:"availableFirmware,omitempty"`
+	// Time the device booted, derived from its uptime.
+	BootTime *metav1.Time `json:"bootTime,omitempty"`
+	// WiFi signal strength in dBm.
+	WiFiRSSI int `json:"wifiRSSI,omitempty"`
+	// Internal temperature in degrees Celsius.
+	Temperature *resource.Quantity `json:"temperature,omitempty"`
+}
@@ -0,0 +1,68 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeviceProfile describes how to talk to devices without a built-in driver.
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type DeviceProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec DeviceProfileSpec `json:"spec,omitempty"`
+}
+
+type DeviceProfileSpec struct {
+	// Profile for RollerShutters with the GenericHTTP device type.
+	RollerShutter *RollerShutterHTTPProfile `json:"rollerShutter,omitempty"`
+}
+
+// RollerShutterHTTPProfile declares the HTTP requests to control a roller shutter.
+type RollerShutterHTTPProfile struct {
+	// Request returning the JSON status document.
+	Status HTTPRequestTemplate `json:"status"`
+	// Request moving the shutter.
+	// Templates can reference the target position via {{ .Position }}.
+	Move HTTPRequestTemplate `json:"move"`
+	// Request stopping the shutter.
+	Stop *HTTPRequestTemplate `json:"stop,omitempty"`
+	// JSONPath expression selecting the position in percentage open
+	// from the status document, e.g. "{.position}".
+	PositionJSONPath string `json:"positionJSONPath"`
+	// JSONPath expression selecting the movement state
+	// from the status document, e.g. "{.state}".
+	StateJSONPath string `json:"stateJSONPath"`
+	// State value reported while the shutter is opening.
+	OpeningState string `json:"openingState"`
+	// State value reported while the shutter is closing.
+	// All other values are considered idle.
+	ClosingState string `json:"closingState"`
+}
+
+// HTTPRequestTemplate describes a HTTP request relative to the device endpoint.
+// Path and Body are Go text/templates.
+type HTTPRequestTemplate struct {
+	// HTTP method to use.
+	// +kubebuilder:default="GET"
+	// +kubebuilder:validation:Enum=GET;POST;PUT
+	Method string `json:"method,omitempty"`
+	// Path and query relative to the device endpoint,
+	// e.g. "cover/0?position={{ .Position }}".
+	Path string `json:"path"`
+	// JSON request body.
+	Body string `json:"body,omitempty"`
+}
+
+// DeviceProfileList contains a list of DeviceProfiles
+// +kubebuilder:object:root=true
+type DeviceProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DeviceProfile `json:"items"`
+}
+
+func init() {
+	register(&DeviceProfile{}, &DeviceProfileList{})
+}
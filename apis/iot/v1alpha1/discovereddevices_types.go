@@ -0,0 +1,83 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DiscoveredDevice is a device found on the network via mDNS.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Model",type="string",JSONPath=".status.model"
+// +kubebuilder:printcolumn:name="MAC",type="string",JSONPath=".status.mac"
+// +kubebuilder:printcolumn:name="Address",type="string",JSONPath=".status.address"
+// +kubebuilder:printcolumn:name="Approved",type="boolean",JSONPath=".spec.approved"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type DiscoveredDevice struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DiscoveredDeviceSpec   `json:"spec,omitempty"`
+	Status DiscoveredDeviceStatus `json:"status,omitempty"`
+}
+
+type DiscoveredDeviceSpec struct {
+	// Address to query the device identity from,
+	// for devices that are not discovered via mDNS.
+	// Use a hostname to follow IP address changes.
+	Address string `json:"address,omitempty"`
+	// Approves adopting the device,
+	// creating the suggested RollerShutter, Switch or Light
+	// with the same name in the same namespace.
+	// The endpoint URL of the adopted object is kept up-to-date
+	// when the device address changes.
+	Approved bool `json:"approved,omitempty"`
+}
+
+type DiscoveredDeviceStatus struct {
+	// The most recent generation observed by the controller.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions is a list of status conditions ths object is in.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// URL the device was last seen at.
+	Address string `json:"address,omitempty"`
+	// ID of the device, e.g. "shellyplus2pm-a8032ab12345".
+	DeviceID string `json:"deviceID,omitempty"`
+	// Model or device type.
+	Model string `json:"model,omitempty"`
+	// MAC address, upper case without separators.
+	MAC string `json:"mac,omitempty"`
+	// Firmware version.
+	Firmware     string       `json:"firmware,omitempty"`
+	LastSeenTime *metav1.Time `json:"lastSeenTime,omitempty"`
+	// RollerShutter spec to control the device with,
+	// if the device is configured to drive a roller shutter.
+	SuggestedRollerShutter *RollerShutterSpec `json:"suggestedRollerShutter,omitempty"`
+	// Switch spec to control the device with,
+	// if the device is a relay.
+	SuggestedSwitch *SwitchSpec `json:"suggestedSwitch,omitempty"`
+	// Light spec to control the device with,
+	// if the device is a dimmer or LED controller.
+	SuggestedLight *LightSpec `json:"suggestedLight,omitempty"`
+}
+
+const (
+	// Condition indicating whether the device has been adopted
+	DiscoveredDeviceAdopted = "Adopted"
+	// Condition indicating whether the device identity could be queried
+	DiscoveredDeviceIdentified = "Identified"
+)
+
+// Label on objects created by adopting a DiscoveredDevice.
+const DiscoveredDeviceLabel = "iot.thetechnick.ninja/discovered-device"
+
+// DiscoveredDeviceList contains a list of DiscoveredDevices
+// +kubebuilder:object:root=true
+type DiscoveredDeviceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DiscoveredDevice `json:"items"`
+}
+
+func init() {
+	register(&DiscoveredDevice{}, &DiscoveredDeviceList{})
+}
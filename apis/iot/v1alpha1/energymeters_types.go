@@ -0,0 +1,71 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EnergyMeter periodically reads power and energy consumption from a device.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Power",type="string",JSONPath=".status.power"
+// +kubebuilder:printcolumn:name="Total Energy",type="string",JSONPath=".status.totalEnergy"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type EnergyMeter struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EnergyMeterSpec   `json:"spec,omitempty"`
+	Status EnergyMeterStatus `json:"status,omitempty"`
+}
+
+type EnergyMeterSpec struct {
+	// Endpoint device type.
+	DeviceType string         `json:"deviceType"`
+	Endpoint   DeviceEndpoint `json:"endpoint"`
+	// Meter channel of the device.
+	Channel int `json:"channel,omitempty"`
+	// Interval to poll the device in.
+	// +kubebuilder:default="60s"
+	PollInterval metav1.Duration `json:"pollInterval,omitempty"`
+}
+
+type EnergyMeterStatus struct {
+	// The most recent generation observed by the controller.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions is a list of status conditions ths object is in.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Active power in Watts.
+	Power *resource.Quantity `json:"power,omitempty"`
+	// Voltage in Volts.
+	Voltage *resource.Quantity `json:"voltage,omitempty"`
+	// Current in Amperes.
+	Current *resource.Quantity `json:"current,omitempty"`
+	// Total consumed energy in Watt-hours.
+	// Accumulated by the operator, so it survives device counter resets.
+	TotalEnergy *resource.Quantity `json:"totalEnergy,omitempty"`
+	// Last energy counter value reported by the device in Watt-hours.
+	DeviceTotalEnergy *resource.Quantity `json:"deviceTotalEnergy,omitempty"`
+	// Timestamp of the last device energy counter reset,
+	// e.g. caused by a device reboot.
+	LastCounterResetTime *metav1.Time `json:"lastCounterResetTime,omitempty"`
+	// Timestamp of the last reading.
+	LastReadingTime *metav1.Time `json:"lastReadingTime,omitempty"`
+}
+
+const (
+	// Condition indicating whether the device can be contacted
+	EnergyMeterReachable = "Reachable"
+)
+
+// EnergyMeterList contains a list of EnergyMeters
+// +kubebuilder:object:root=true
+type EnergyMeterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EnergyMeter `json:"items"`
+}
+
+func init() {
+	register(&EnergyMeter{}, &EnergyMeterList{})
+}
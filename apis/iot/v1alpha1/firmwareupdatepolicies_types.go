@@ -0,0 +1,110 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FirmwareUpdatePolicy rolls out firmware updates to RollerShutter devices.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Pending",type="integer",JSONPath=".status.pending"
+// +kubebuilder:printcolumn:name="Updating",type="integer",JSONPath=".status.updating"
+// +kubebuilder:printcolumn:name="Failed",type="integer",JSONPath=".status.failed"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type FirmwareUpdatePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FirmwareUpdatePolicySpec   `json:"spec,omitempty"`
+	Status FirmwareUpdatePolicyStatus `json:"status,omitempty"`
+}
+
+type FirmwareUpdatePolicySpec struct {
+	// Selects RollerShutters in the same namespace to update.
+	RollerShutterSelector metav1.LabelSelector `json:"rollerShutterSelector"`
+	// Maximum number of devices updating at the same time.
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrent int `json:"maxConcurrent,omitempty"`
+	// Updates are only started within the maintenance window.
+	// Without a window, updates are started right away.
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+	// Time a device may take to come back with the new firmware,
+	// before the update is considered failed.
+	// +kubebuilder:default="10m"
+	UpdateTimeout metav1.Duration `json:"updateTimeout,omitempty"`
+	// Interval to check devices in.
+	// +kubebuilder:default="1m"
+	PollInterval metav1.Duration `json:"pollInterval,omitempty"`
+}
+
+// MaintenanceWindow is a daily time window.
+type MaintenanceWindow struct {
+	// Start of the window, e.g. "02:00".
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+	// End of the window, e.g. "04:00".
+	// Windows ending before they start span midnight.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	End string `json:"end"`
+	// IANA time zone of start and end, e.g. "Europe/Berlin".
+	// +kubebuilder:default="UTC"
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+type FirmwareUpdatePolicyStatus struct {
+	// The most recent generation observed by the controller.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions is a list of status conditions ths object is in.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Update progress per device.
+	Devices []FirmwareUpdateDeviceStatus `json:"devices,omitempty"`
+	// Number of devices waiting for an update.
+	Pending int `json:"pending"`
+	// Number of devices currently updating.
+	Updating int `json:"updating"`
+	// Number of devices that failed to update.
+	Failed int `json:"failed"`
+}
+
+type FirmwareUpdateDeviceStatus struct {
+	// Name of the RollerShutter.
+	Name  string              `json:"name"`
+	Phase FirmwareUpdatePhase `json:"phase"`
+	// Firmware version before the update.
+	FromVersion string `json:"fromVersion,omitempty"`
+	// Firmware version to update to.
+	ToVersion string `json:"toVersion,omitempty"`
+	// Time the update was triggered.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// Human readable details, e.g. why the update is waiting.
+	Message string `json:"message,omitempty"`
+}
+
+type FirmwareUpdatePhase string
+
+const (
+	FirmwareUpdatePhaseUpToDate FirmwareUpdatePhase = "UpToDate"
+	FirmwareUpdatePhasePending  FirmwareUpdatePhase = "Pending"
+	FirmwareUpdatePhaseUpdating FirmwareUpdatePhase = "Updating"
+	FirmwareUpdatePhaseUpdated  FirmwareUpdatePhase = "Updated"
+	// Failed updates are not retried until a newer firmware is available.
+	FirmwareUpdatePhaseFailed FirmwareUpdatePhase = "Failed"
+)
+
+const (
+	// Condition indicating whether updates may currently be started.
+	FirmwareUpdatePolicyInMaintenanceWindow = "InMaintenanceWindow"
+)
+
+// FirmwareUpdatePolicyList contains a list of FirmwareUpdatePolicies
+// +kubebuilder:object:root=true
+type FirmwareUpdatePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FirmwareUpdatePolicy `json:"items"`
+}
+
+func init() {
+	register(&FirmwareUpdatePolicy{}, &FirmwareUpdatePolicyList{})
+}
@@ -0,0 +1,71 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Action",type="string",JSONPath=".spec.action"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type GateRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GateRequestSpec   `json:"spec,omitempty"`
+	Status GateRequestStatus `json:"status,omitempty"`
+}
+
+type GateRequestSpec struct {
+	// Action to perform.
+	// Stop only acts while an Open or Close request is moving the gate.
+	// +kubebuilder:validation:Enum=Open;Close;Stop
+	Action GateAction                  `json:"action"`
+	Gate   corev1.LocalObjectReference `json:"gate"`
+}
+
+type GateAction string
+
+const (
+	GateActionOpen  GateAction = "Open"
+	GateActionClose GateAction = "Close"
+	GateActionStop  GateAction = "Stop"
+)
+
+type GateRequestStatus struct {
+	// The most recent generation observed by the controller.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions is a list of status conditions ths object is in.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	Phase      GateRequestPhase   `json:"phase,omitempty"`
+	// Time the gate was triggered.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+}
+
+const (
+	// Condition indicating whether the request was completed
+	GateRequestCompleted = "Completed"
+)
+
+type GateRequestPhase string
+
+const (
+	GateRequestPhasePending   GateRequestPhase = "Pending"
+	GateRequestPhaseMoving    GateRequestPhase = "Moving"
+	GateRequestPhaseCompleted GateRequestPhase = "Completed"
+	GateRequestPhaseFailed    GateRequestPhase = "Failed"
+)
+
+// GateRequestList contains a list of GateRequests
+// +kubebuilder:object:root=true
+type GateRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GateRequest `json:"items"`
+}
+
+func init() {
+	register(&GateRequest{}, &GateRequestList{})
+}
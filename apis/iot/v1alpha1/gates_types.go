@@ -0,0 +1,76 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Gate is a garage door or gate driven by a relay pulse,
+// with end positions reported by contact inputs.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type Gate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GateSpec   `json:"spec,omitempty"`
+	Status GateStatus `json:"status,omitempty"`
+}
+
+type GateSpec struct {
+	// Endpoint device type.
+	DeviceType string         `json:"deviceType"`
+	Endpoint   DeviceEndpoint `json:"endpoint"`
+	// Relay channel triggering the gate motor.
+	// +kubebuilder:validation:Minimum=0
+	Channel int `json:"channel,omitempty"`
+	// Duration of the relay pulse.
+	// +kubebuilder:default="1s"
+	PulseDuration metav1.Duration `json:"pulseDuration,omitempty"`
+	// Input that is active while the gate is closed.
+	// +kubebuilder:validation:Minimum=0
+	ClosedInput int `json:"closedInput"`
+	// Input that is active while the gate is open.
+	// Without an open input, the gate is considered open when it's not closed.
+	// +kubebuilder:validation:Minimum=0
+	OpenInput *int `json:"openInput,omitempty"`
+	// Maximum time for the gate to reach its end position,
+	// before a GateRequest is marked as failed.
+	// +kubebuilder:default="30s"
+	TravelTime metav1.Duration `json:"travelTime,omitempty"`
+}
+
+type GateStatus struct {
+	// The most recent generation observed by the controller.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions is a list of status conditions ths object is in.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	State      GateState          `json:"state,omitempty"`
+}
+
+const (
+	// Condition indicating whether the device can be contacted
+	GateReachable = "Reachable"
+)
+
+type GateState string
+
+const (
+	GateStateOpen   GateState = "Open"
+	GateStateClosed GateState = "Closed"
+	// Neither open nor closed, either moving or stopped in between.
+	GateStateIntermediate GateState = "Intermediate"
+)
+
+// GateList contains a list of Gates
+// +kubebuilder:object:root=true
+type GateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Gate `json:"items"`
+}
+
+func init() {
+	register(&Gate{}, &GateList{})
+}
@@ -0,0 +1,97 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Light is a dimmable and/or colored light.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="Brightness",type="number",JSONPath=".status.brightness"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type Light struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LightSpec   `json:"spec,omitempty"`
+	Status LightStatus `json:"status,omitempty"`
+}
+
+// Desired settings are applied whenever the spec changes,
+// manual changes on the device are kept.
+// Settings left empty are not changed.
+type LightSpec struct {
+	// Endpoint device type.
+	DeviceType string         `json:"deviceType"`
+	Endpoint   DeviceEndpoint `json:"endpoint"`
+	// Light channel of the device.
+	Channel int `json:"channel,omitempty"`
+	// Desired state of the light.
+	// +kubebuilder:validation:Enum=On;Off
+	State LightState `json:"state,omitempty"`
+	// Desired brightness in percent.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Brightness *int `json:"brightness,omitempty"`
+	// Desired color temperature in Kelvin.
+	// Only supported by white lights with adjustable color temperature.
+	ColorTemperature *int `json:"colorTemperature,omitempty"`
+	// Desired color.
+	// Only supported by color lights.
+	Color *LightColor `json:"color,omitempty"`
+}
+
+type LightColor struct {
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=255
+	Red int `json:"red"`
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=255
+	Green int `json:"green"`
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=255
+	Blue int `json:"blue"`
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=255
+	White int `json:"white,omitempty"`
+}
+
+type LightStatus struct {
+	// The most recent generation observed by the controller.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions is a list of status conditions ths object is in.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Reported state of the light.
+	State LightState `json:"state,omitempty"`
+	// Reported brightness in percent.
+	Brightness int `json:"brightness"`
+	// Reported color temperature in Kelvin.
+	ColorTemperature int `json:"colorTemperature,omitempty"`
+	// Reported color.
+	Color *LightColor `json:"color,omitempty"`
+}
+
+const (
+	// Condition indicating whether the device can be contacted
+	LightReachable = "Reachable"
+)
+
+type LightState string
+
+const (
+	LightStateOn  LightState = "On"
+	LightStateOff LightState = "Off"
+)
+
+// LightList contains a list of Lights
+// +kubebuilder:object:root=true
+type LightList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Light `json:"items"`
+}
+
+func init() {
+	register(&Light{}, &LightList{})
+}
@@ -0,0 +1,77 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MovementHistory keeps a log of the movements of a RollerShutter,
+// that outlives garbage collected RollerShutterRequests.
+// MovementHistories are created by the operator and named after their RollerShutter.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="RollerShutter",type="string",JSONPath=".spec.rollerShutter.name"
+// +kubebuilder:printcolumn:name="Last Movement",type="date",JSONPath=".status.entries[0].startTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type MovementHistory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MovementHistorySpec   `json:"spec,omitempty"`
+	Status MovementHistoryStatus `json:"status,omitempty"`
+}
+
+type MovementHistorySpec struct {
+	// RollerShutter the movements are recorded for.
+	RollerShutter corev1.LocalObjectReference `json:"rollerShutter"`
+	// Maximum number of entries to keep, older entries are dropped.
+	// +kubebuilder:default=50
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=500
+	Limit int `json:"limit,omitempty"`
+}
+
+type MovementHistoryStatus struct {
+	// Recorded movements, newest first.
+	Entries []MovementRecord `json:"entries,omitempty"`
+}
+
+type MovementRecord struct {
+	// Name of the RollerShutterRequest.
+	Request string `json:"request"`
+	// Who created the request,
+	// e.g. "ProtectionPolicy/wind" or the name of the field manager.
+	RequestedBy string `json:"requestedBy,omitempty"`
+	// Whether the request was automated.
+	Automated bool `json:"automated,omitempty"`
+	// Position in percentage open when the movement started.
+	FromPosition *int `json:"fromPosition,omitempty"`
+	// Requested position in percentage open.
+	TargetPosition int `json:"targetPosition"`
+	// Position in percentage open when the request completed.
+	ToPosition int `json:"toPosition"`
+	// Time the request started to be executed.
+	// Creation time for requests that were never executed.
+	StartTime metav1.Time `json:"startTime"`
+	// Time from start to completion.
+	Duration metav1.Duration `json:"duration"`
+	// Final phase of the request, e.g. Completed or Rejected.
+	Outcome RollerShutterRequestPhase `json:"outcome"`
+	// Reason of the Completed condition, e.g. AtPosition or Locked.
+	Reason string `json:"reason,omitempty"`
+	// Why the device stopped the movement, e.g. Obstacle.
+	// Empty for normal stops.
+	StopReason string `json:"stopReason,omitempty"`
+}
+
+// MovementHistoryList contains a list of MovementHistories
+// +kubebuilder:object:root=true
+type MovementHistoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MovementHistory `json:"items"`
+}
+
+func init() {
+	register(&MovementHistory{}, &MovementHistoryList{})
+}
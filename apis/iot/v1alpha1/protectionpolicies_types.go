@@ -0,0 +1,101 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ProtectionPolicy retracts RollerShutters and locks them,
+// while a sensor reading exceeds a threshold.
+// e.g. to protect awnings and external blinds from wind and rain.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Reading",type="string",JSONPath=".status.lastReading"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type ProtectionPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ProtectionPolicySpec   `json:"spec,omitempty"`
+	Status ProtectionPolicyStatus `json:"status,omitempty"`
+}
+
+type ProtectionPolicySpec struct {
+	// Sensor to watch, e.g. a wind or rain sensor.
+	Source SensorSource `json:"source"`
+	// The policy activates when the sensor reading exceeds this threshold.
+	// Boolean readings are interpreted as 1 (true) and 0 (false).
+	Threshold resource.Quantity `json:"threshold"`
+	// Time the sensor reading has to stay at or below the threshold,
+	// before the policy deactivates again.
+	// +kubebuilder:default="15m"
+	CalmDownPeriod metav1.Duration `json:"calmDownPeriod,omitempty"`
+	// Interval to poll the sensor in.
+	// +kubebuilder:default="30s"
+	PollInterval metav1.Duration `json:"pollInterval,omitempty"`
+	// Selects RollerShutters in the same namespace protected by this policy.
+	RollerShutterSelector metav1.LabelSelector `json:"rollerShutterSelector"`
+	// Position protected RollerShutters are moved to while the policy is active.
+	// 100 = completely open, 0 = completely closed.
+	// +kubebuilder:default=100
+	// +optional
+	Position int `json:"position"`
+	// Determines how other RollerShutterRequests are handled while the policy is active.
+	// Hold keeps them pending until the policy deactivates,
+	// Reject completes them without moving the shutter.
+	// +kubebuilder:default="Hold"
+	// +kubebuilder:validation:Enum=Hold;Reject
+	RequestPolicy ProtectionRequestPolicy `json:"requestPolicy,omitempty"`
+}
+
+type ProtectionRequestPolicy string
+
+const (
+	ProtectionRequestPolicyHold   ProtectionRequestPolicy = "Hold"
+	ProtectionRequestPolicyReject ProtectionRequestPolicy = "Reject"
+)
+
+type ProtectionPolicyStatus struct {
+	// The most recent generation observed by the controller.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions is a list of status conditions ths object is in.
+	Conditions []metav1.Condition    `json:"conditions,omitempty"`
+	Phase      ProtectionPolicyPhase `json:"phase,omitempty"`
+	// Last value read from the sensor.
+	LastReading string `json:"lastReading,omitempty"`
+	// Timestamp of the last sensor reading.
+	LastReadingTime *metav1.Time `json:"lastReadingTime,omitempty"`
+	// Timestamp of the last sensor reading exceeding the threshold.
+	// Also set while the sensor can't be read during an activation.
+	LastTriggeredTime *metav1.Time `json:"lastTriggeredTime,omitempty"`
+}
+
+const (
+	// Condition indicating whether the policy is protecting its RollerShutters.
+	ProtectionPolicyActive = "Active"
+	// Condition indicating whether the sensor can be read.
+	ProtectionPolicySensorReachable = "SensorReachable"
+)
+
+// Label set on RollerShutterRequests created by a ProtectionPolicy.
+const ProtectionPolicyLabel = "iot.thetechnick.ninja/protection-policy"
+
+type ProtectionPolicyPhase string
+
+const (
+	ProtectionPolicyPhaseActive   ProtectionPolicyPhase = "Active"
+	ProtectionPolicyPhaseInactive ProtectionPolicyPhase = "Inactive"
+)
+
+// ProtectionPolicyList contains a list of ProtectionPolicies
+// +kubebuilder:object:root=true
+type ProtectionPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ProtectionPolicy `json:"items"`
+}
+
+func init() {
+	register(&ProtectionPolicy{}, &ProtectionPolicyList{})
+}
@@ -22,6 +22,12 @@ type RollerShutterRequestSpec struct {
 	// Desired position for the shutter.
 	Position      int                         `json:"position"`
 	RollerShutter corev1.LocalObjectReference `json:"rollerShutter"`
+	// Automated requests are held back while a
+	// manual override is active on the RollerShutter.
+	Automated bool `json:"automated,omitempty"`
+	// Requests with a higher priority are executed first.
+	// Requests with the same priority are executed in order of creation.
+	Priority int `json:"priority,omitempty"`
 }
 
 type RollerShutterRequestStatus struct {
@@ -30,6 +36,10 @@ type RollerShutterRequestStatus struct {
 	// Conditions is a list of status conditions ths object is in.
 	Conditions []metav1.Condition        `json:"conditions,omitempty"`
 	Phase      RollerShutterRequestPhase `json:"phase,omitempty"`
+	// Time the request started to be executed.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// Position of the shutter when the request started to be executed.
+	StartPosition *int `json:"startPosition,omitempty"`
 }
 
 const (
@@ -43,6 +53,7 @@ const (
 	RollerShutterRequestPhasePending   RollerShutterRequestPhase = "Pending"
 	RollerShutterRequestPhaseMoving    RollerShutterRequestPhase = "Moving"
 	RollerShutterRequestPhaseCompleted RollerShutterRequestPhase = "Completed"
+	RollerShutterRequestPhaseRejected  RollerShutterRequestPhase = "Rejected"
 )
 
 // RollerShutterRequestList contains a list of RollerShutterRequests
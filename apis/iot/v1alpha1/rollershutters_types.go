@@ -1,6 +1,8 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -8,6 +10,7 @@ import (
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Position",type="number",JSONPath=".status.position"
 // +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="MAC",type="string",JSONPath=".status.mac",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 type RollerShutter struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -21,11 +24,136 @@ type RollerShutterSpec struct {
 	// Endpoint device type.
 	DeviceType string                `json:"deviceType"`
 	Endpoint   RollerShutterEndpoint `json:"endpoint"`
+	// Describes the device API for the GenericHTTP device type.
+	GenericHTTP *RollerShutterGenericHTTP `json:"genericHTTP,omitempty"`
+	// Duration that automated RollerShutterRequests are held back
+	// after the shutter was moved without a RollerShutterRequest,
+	// e.g. by pressing the wall switch.
+	// +kubebuilder:default="1h"
+	ManualOverrideHoldOff metav1.Duration `json:"manualOverrideHoldOff,omitempty"`
+	// Refuses RollerShutterRequests while it's freezing,
+	// to protect shutters frozen to the window frame.
+	FrostProtection *RollerShutterFrostProtection `json:"frostProtection,omitempty"`
+	// Prevents closing the shutter while a door or window is open,
+	// to not lock people out.
+	WindowContact *RollerShutterWindowContact `json:"windowContact,omitempty"`
+	// Device settings kept in sync with the device configuration.
+	// Settings left empty are not managed.
+	// Settings the device does not accept are only applied again
+	// after the settings change.
+	Settings *RollerShutterSettings `json:"settings,omitempty"`
+}
+
+type RollerShutterSettings struct {
+	// Only reports drift via the ConfigInSync condition,
+	// without changing the device configuration.
+	DryRun bool `json:"dryRun,omitempty"`
+	// Maximum time the motor runs in one direction.
+	MaxTravelTime *metav1.Duration `json:"maxTravelTime,omitempty"`
+	// State the shutter assumes after power-on.
+	// +kubebuilder:validation:Enum=Stop;Open;Close;Switch
+	DefaultState RollerShutterDefaultState `json:"defaultState,omitempty"`
+	// Wiring of the wall switch inputs.
+	// OpenClose uses one button per direction,
+	// OneButton cycles open, stop, close, stop.
+	// +kubebuilder:validation:Enum=OpenClose;OneButton
+	InputMode RollerShutterInputMode `json:"inputMode,omitempty"`
+	// Stops or reverses the shutter when running into an obstacle.
+	ObstacleDetection *RollerShutterObstacleDetection `json:"obstacleDetection,omitempty"`
+}
+
+type RollerShutterObstacleDetection struct {
+	// Movements during which obstacles are detected.
+	// +kubebuilder:validation:Enum=Disabled;WhileOpening;WhileClosing;WhileMoving
+	Mode RollerShutterObstacleMode `json:"mode,omitempty"`
+	// Action taken when an obstacle is detected.
+	// +kubebuilder:validation:Enum=Stop;Reverse
+	Action RollerShutterObstacleAction `json:"action,omitempty"`
+	// Power consumption in Watts above which an obstacle is detected.
+	// +kubebuilder:validation:Minimum=0
+	Power *int `json:"power,omitempty"`
+	// Time after the motor started before detection begins,
+	// to ignore the power peak when starting.
+	// Shelly devices only support full seconds.
+	Delay *metav1.Duration `json:"delay,omitempty"`
+}
+
+type RollerShutterDefaultState string
+
+const (
+	RollerShutterDefaultStateStop   RollerShutterDefaultState = "Stop"
+	RollerShutterDefaultStateOpen   RollerShutterDefaultState = "Open"
+	RollerShutterDefaultStateClose  RollerShutterDefaultState = "Close"
+	RollerShutterDefaultStateSwitch RollerShutterDefaultState = "Switch"
+)
+
+type RollerShutterInputMode string
+
+const (
+	RollerShutterInputModeOpenClose RollerShutterInputMode = "OpenClose"
+	RollerShutterInputModeOneButton RollerShutterInputMode = "OneButton"
+)
+
+type RollerShutterObstacleMode string
+
+const (
+	RollerShutterObstacleModeDisabled     RollerShutterObstacleMode = "Disabled"
+	RollerShutterObstacleModeWhileOpening RollerShutterObstacleMode = "WhileOpening"
+	RollerShutterObstacleModeWhileClosing RollerShutterObstacleMode = "WhileClosing"
+	RollerShutterObstacleModeWhileMoving  RollerShutterObstacleMode = "WhileMoving"
+)
+
+type RollerShutterObstacleAction string
+
+const (
+	RollerShutterObstacleActionStop    RollerShutterObstacleAction = "Stop"
+	RollerShutterObstacleActionReverse RollerShutterObstacleAction = "Reverse"
+)
+
+type RollerShutterFrostProtection struct {
+	// Temperature sensor to read.
+	Source SensorSource `json:"source"`
+	// RollerShutterRequests are refused while the
+	// temperature reading is below this threshold.
+	// +kubebuilder:default="0"
+	Threshold resource.Quantity `json:"threshold"`
+}
+
+type RollerShutterWindowContact struct {
+	// Door/window contact sensor to read.
+	// Readings of "open", "on", "true" and "1" are considered open.
+	Source SensorSource `json:"source"`
+	// RollerShutterRequests to a position below this value are
+	// blocked while the door or window is open.
+	// +kubebuilder:default=100
+	// +optional
+	MinPosition int `json:"minPosition"`
+	// Determines how blocked RollerShutterRequests are handled.
+	// Hold keeps them pending until the door or window is closed,
+	// Reject completes them without moving the shutter.
+	// +kubebuilder:default="Hold"
+	// +kubebuilder:validation:Enum=Hold;Reject
+	RequestPolicy ProtectionRequestPolicy `json:"requestPolicy,omitempty"`
+}
+
+type RollerShutterGenericHTTP struct {
+	// Inline device profile.
+	Profile *RollerShutterHTTPProfile `json:"profile,omitempty"`
+	// DeviceProfile object in the same namespace,
+	// used when no inline profile is given.
+	ProfileRef *corev1.LocalObjectReference `json:"profileRef,omitempty"`
 }
 
 type RollerShutterEndpoint struct {
 	// URL to contact the device under.
-	URL string `json:"url"`
+	// Either url or mqtt must be set.
+	URL string `json:"url,omitempty"`
+	// ID of the device, e.g. "shellyswitch25-C45BBE".
+	// Used to match state change callbacks from the device,
+	// which have to pass it as id parameter.
+	DeviceID string `json:"deviceID,omitempty"`
+	// Communicate with the device via MQTT instead of HTTP.
+	MQTT *MQTTEndpoint `json:"mqtt,omitempty"`
 }
 
 type RollerShutterStatus struct {
@@ -39,11 +167,37 @@ type RollerShutterStatus struct {
 	Position int `json:"position"`
 	// Power consumption in Watts.
 	Power int `json:"power"`
+	// Timestamp of the last position change,
+	// that was not caused by a RollerShutterRequest.
+	LastManualOverrideTime *metav1.Time `json:"lastManualOverrideTime,omitempty"`
+	// MAC address of the device, recorded on first contact.
+	// Clear to accept a replaced device.
+	MAC string `json:"mac,omitempty"`
+	// Information about the device hardware and firmware.
+	DeviceInfo *DeviceInfo `json:"deviceInfo,omitempty"`
 }
 
 const (
 	// Condition indicating whether the device can be contacted
 	RollerShutterReachable = "Reachable"
+	// Condition indicating whether the shutter was moved manually
+	// and automated requests are held back.
+	RollerShutterManualOverride = "ManualOverride"
+	// Condition indicating whether the shutter is locked
+	// and only accepts requests from the lock holder.
+	RollerShutterLocked = "Locked"
+	// Condition indicating whether a different device
+	// than the recorded one answers at the endpoint URL.
+	RollerShutterIdentityMismatch = "IdentityMismatch"
+	// Condition indicating whether the device reports overheating
+	RollerShutterOverTemperature = "OverTemperature"
+	// Condition indicating whether the WiFi signal of the device is weak
+	RollerShutterWeakSignal = "WeakSignal"
+	// Condition indicating whether a firmware update is available
+	RollerShutterUpdateAvailable = "UpdateAvailable"
+	// Condition indicating whether the device configuration
+	// matches the settings in the spec.
+	RollerShutterConfigInSync = "ConfigInSync"
 )
 
 type RollerShutterPhase string
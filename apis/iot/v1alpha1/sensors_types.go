@@ -0,0 +1,100 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Sensor periodically reads environmental data like temperature,
+// humidity and illuminance from a device.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Device Type",type="string",JSONPath=".spec.deviceType"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type Sensor struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SensorSpec   `json:"spec,omitempty"`
+	Status SensorStatus `json:"status,omitempty"`
+}
+
+type SensorSpec struct {
+	// Endpoint device type.
+	DeviceType string         `json:"deviceType"`
+	Endpoint   DeviceEndpoint `json:"endpoint"`
+	// Sensor channel of the device.
+	// e.g. 100-104 for sensors connected to a Shelly Plus Add-on.
+	Channel int `json:"channel,omitempty"`
+	// Maps values of the JSON document to readings.
+	// Only used by the HTTPJSON device type.
+	Readings []SensorReadingMapping `json:"readings,omitempty"`
+	// Interval to poll the device in.
+	// +kubebuilder:default="60s"
+	PollInterval metav1.Duration `json:"pollInterval,omitempty"`
+}
+
+type SensorReadingMapping struct {
+	// Name of the reading.
+	Name string            `json:"name"`
+	Type SensorReadingType `json:"type"`
+	// Unit of the reading.
+	Unit string `json:"unit,omitempty"`
+	// JSONPath expression selecting the value within the JSON document.
+	// e.g. "{.temperature}"
+	JSONPath string `json:"jsonPath"`
+}
+
+type SensorStatus struct {
+	// The most recent generation observed by the controller.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions is a list of status conditions ths object is in.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Last values read from the device.
+	Readings []SensorReading `json:"readings,omitempty"`
+}
+
+type SensorReading struct {
+	// Name of the reading.
+	Name string            `json:"name"`
+	Type SensorReadingType `json:"type"`
+	// Value as reported by the device.
+	Value string `json:"value"`
+	// Unit of the value.
+	Unit string `json:"unit,omitempty"`
+	// Time the value was read.
+	Time metav1.Time `json:"time"`
+}
+
+// +kubebuilder:validation:Enum=Temperature;Humidity;Illuminance;Battery;Contact;Generic
+type SensorReadingType string
+
+const (
+	// Temperature in degrees Celsius.
+	SensorReadingTypeTemperature SensorReadingType = "Temperature"
+	// Relative humidity in percent.
+	SensorReadingTypeHumidity SensorReadingType = "Humidity"
+	// Illuminance in Lux.
+	SensorReadingTypeIlluminance SensorReadingType = "Illuminance"
+	// Battery level in percent.
+	SensorReadingTypeBattery SensorReadingType = "Battery"
+	// Door/window contact, "open" or "close".
+	SensorReadingTypeContact SensorReadingType = "Contact"
+	SensorReadingTypeGeneric SensorReadingType = "Generic"
+)
+
+const (
+	// Condition indicating whether the device can be contacted
+	SensorReachable = "Reachable"
+)
+
+// SensorList contains a list of Sensors
+// +kubebuilder:object:root=true
+type SensorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Sensor `json:"items"`
+}
+
+func init() {
+	register(&Sensor{}, &SensorList{})
+}
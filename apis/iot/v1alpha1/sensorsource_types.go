@@ -0,0 +1,27 @@
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// SensorSource describes where to read a sensor value from.
+// Either a Sensor object or a URL and JSONPath expression have to be set.
+type SensorSource struct {
+	// Sensor object in the same namespace to read from.
+	SensorRef *SensorReference `json:"sensorRef,omitempty"`
+	// URL to fetch a JSON document containing the sensor reading from.
+	URL string `json:"url,omitempty"`
+	// JSONPath expression selecting the reading within the JSON document.
+	// e.g. "{.wind.speed}"
+	JSONPath string `json:"jsonPath,omitempty"`
+}
+
+type SensorReference struct {
+	// Name of the Sensor object.
+	Name string `json:"name"`
+	// Name of the reading.
+	Reading string `json:"reading"`
+	// Maximum age of the reading, before it is considered stale.
+	// Battery powered sensors only report when they wake up,
+	// so this should cover their wake-up interval.
+	// Defaults to 3 times the poll interval of the Sensor.
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+}
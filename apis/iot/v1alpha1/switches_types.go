@@ -0,0 +1,69 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Switch is a relay switching e.g. lights or pumps on and off.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="Power",type="number",JSONPath=".status.power"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type Switch struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SwitchSpec   `json:"spec,omitempty"`
+	Status SwitchStatus `json:"status,omitempty"`
+}
+
+type SwitchSpec struct {
+	// Endpoint device type.
+	DeviceType string         `json:"deviceType"`
+	Endpoint   DeviceEndpoint `json:"endpoint"`
+	// Relay channel of the device.
+	// +kubebuilder:validation:Minimum=0
+	Channel int `json:"channel,omitempty"`
+	// Desired state of the switch.
+	// The state is applied whenever the spec changes,
+	// manual changes on the device are kept.
+	// Leave empty to only observe the switch.
+	// +kubebuilder:validation:Enum=On;Off
+	State SwitchState `json:"state,omitempty"`
+}
+
+type SwitchStatus struct {
+	// The most recent generation observed by the controller.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions is a list of status conditions ths object is in.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Reported state of the switch.
+	State SwitchState `json:"state,omitempty"`
+	// Power consumption in Watts.
+	Power int `json:"power"`
+}
+
+const (
+	// Condition indicating whether the device can be contacted
+	SwitchReachable = "Reachable"
+)
+
+type SwitchState string
+
+const (
+	SwitchStateOn  SwitchState = "On"
+	SwitchStateOff SwitchState = "Off"
+)
+
+// SwitchList contains a list of Switches
+// +kubebuilder:object:root=true
+type SwitchList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Switch `json:"items"`
+}
+
+func init() {
+	register(&Switch{}, &SwitchList{})
+}
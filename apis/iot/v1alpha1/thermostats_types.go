@@ -0,0 +1,101 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Thermostat controls a radiator valve.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Temperature",type="string",JSONPath=".status.temperature"
+// +kubebuilder:printcolumn:name="Target",type="string",JSONPath=".status.targetTemperature"
+// +kubebuilder:printcolumn:name="Valve",type="number",JSONPath=".status.valvePosition"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type Thermostat struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ThermostatSpec   `json:"spec,omitempty"`
+	Status ThermostatStatus `json:"status,omitempty"`
+}
+
+// Desired settings are applied whenever the spec changes,
+// battery powered devices receive them the next time they are reachable.
+type ThermostatSpec struct {
+	// Endpoint device type.
+	DeviceType string         `json:"deviceType"`
+	Endpoint   DeviceEndpoint `json:"endpoint"`
+	// Thermostat channel of the device.
+	// +kubebuilder:validation:Minimum=0
+	Channel int `json:"channel,omitempty"`
+	// Operating mode.
+	// Auto heats to the target temperature,
+	// Boost fully opens the valve for the boost duration,
+	// Off closes the valve.
+	// +kubebuilder:default="Auto"
+	// +kubebuilder:validation:Enum=Auto;Boost;Off
+	Mode ThermostatMode `json:"mode,omitempty"`
+	// Target temperature in degrees Celsius.
+	TargetTemperature *resource.Quantity `json:"targetTemperature,omitempty"`
+	// Disables the schedule stored on the device,
+	// so the target temperature is not changed by the device itself.
+	ScheduleOverride bool `json:"scheduleOverride,omitempty"`
+	// Duration of Boost mode.
+	// +kubebuilder:default="30m"
+	BoostDuration metav1.Duration `json:"boostDuration,omitempty"`
+	// Interval to poll the device in.
+	// +kubebuilder:default="5m"
+	PollInterval metav1.Duration `json:"pollInterval,omitempty"`
+	// Interval to retry applying settings to an unreachable device.
+	// +kubebuilder:default="1m"
+	RetryInterval metav1.Duration `json:"retryInterval,omitempty"`
+}
+
+type ThermostatMode string
+
+const (
+	ThermostatModeAuto  ThermostatMode = "Auto"
+	ThermostatModeBoost ThermostatMode = "Boost"
+	ThermostatModeOff   ThermostatMode = "Off"
+)
+
+type ThermostatStatus struct {
+	// The most recent generation applied to the device.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions is a list of status conditions ths object is in.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Measured temperature in degrees Celsius.
+	Temperature *resource.Quantity `json:"temperature,omitempty"`
+	// Target temperature reported by the device in degrees Celsius.
+	TargetTemperature *resource.Quantity `json:"targetTemperature,omitempty"`
+	// Valve position in percent open.
+	ValvePosition int `json:"valvePosition"`
+	// Battery level in percent.
+	Battery int `json:"battery"`
+	// Whether the schedule stored on the device is enabled.
+	ScheduleEnabled bool `json:"scheduleEnabled"`
+	// Whether the device detected an open window.
+	WindowOpen bool `json:"windowOpen"`
+	// Timestamp of the last successful contact with the device.
+	LastSeenTime *metav1.Time `json:"lastSeenTime,omitempty"`
+}
+
+const (
+	// Condition indicating whether the device can be contacted
+	ThermostatReachable = "Reachable"
+	// Condition indicating whether the desired settings have been applied to the device.
+	ThermostatSynced = "Synced"
+)
+
+// ThermostatList contains a list of Thermostats
+// +kubebuilder:object:root=true
+type ThermostatList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Thermostat `json:"items"`
+}
+
+func init() {
+	register(&Thermostat{}, &ThermostatList{})
+}
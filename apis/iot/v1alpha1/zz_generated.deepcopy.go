@@ -5,31 +5,70 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RollerShutter) DeepCopyInto(out *RollerShutter) {
+func (in *DeviceEndpoint) DeepCopyInto(out *DeviceEndpoint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceEndpoint.
+func (in *DeviceEndpoint) DeepCopy() *DeviceEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceInfo) DeepCopyInto(out *DeviceInfo) {
+	*out = *in
+	if in.BootTime != nil {
+		in, out := &in.BootTime, &out.BootTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Temperature != nil {
+		in, out := &in.Temperature, &out.Temperature
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceInfo.
+func (in *DeviceInfo) DeepCopy() *DeviceInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceProfile) DeepCopyInto(out *DeviceProfile) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	in.Status.DeepCopyInto(&out.Status)
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollerShutter.
-func (in *RollerShutter) DeepCopy() *RollerShutter {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceProfile.
+func (in *DeviceProfile) DeepCopy() *DeviceProfile {
 	if in == nil {
 		return nil
 	}
-	out := new(RollerShutter)
+	out := new(DeviceProfile)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RollerShutter) DeepCopyObject() runtime.Object {
+func (in *DeviceProfile) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -37,46 +76,110 @@ func (in *RollerShutter) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RollerShutterEndpoint) DeepCopyInto(out *RollerShutterEndpoint) {
+func (in *DeviceProfileList) DeepCopyInto(out *DeviceProfileList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DeviceProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollerShutterEndpoint.
-func (in *RollerShutterEndpoint) DeepCopy() *RollerShutterEndpoint {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceProfileList.
+func (in *DeviceProfileList) DeepCopy() *DeviceProfileList {
 	if in == nil {
 		return nil
 	}
-	out := new(RollerShutterEndpoint)
+	out := new(DeviceProfileList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DeviceProfileList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceProfileSpec) DeepCopyInto(out *DeviceProfileSpec) {
+	*out = *in
+	if in.RollerShutter != nil {
+		in, out := &in.RollerShutter, &out.RollerShutter
+		*out = new(RollerShutterHTTPProfile)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceProfileSpec.
+func (in *DeviceProfileSpec) DeepCopy() *DeviceProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceProfileSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RollerShutterList) DeepCopyInto(out *RollerShutterList) {
+func (in *DiscoveredDevice) DeepCopyInto(out *DiscoveredDevice) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiscoveredDevice.
+func (in *DiscoveredDevice) DeepCopy() *DiscoveredDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(DiscoveredDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DiscoveredDevice) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiscoveredDeviceList) DeepCopyInto(out *DiscoveredDeviceList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]RollerShutter, len(*in))
+		*out = make([]DiscoveredDevice, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollerShutterList.
-func (in *RollerShutterList) DeepCopy() *RollerShutterList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiscoveredDeviceList.
+func (in *DiscoveredDeviceList) DeepCopy() *DiscoveredDeviceList {
 	if in == nil {
 		return nil
 	}
-	out := new(RollerShutterList)
+	out := new(DiscoveredDeviceList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RollerShutterList) DeepCopyObject() runtime.Object {
+func (in *DiscoveredDeviceList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -84,7 +187,63 @@ func (in *RollerShutterList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RollerShutterRequest) DeepCopyInto(out *RollerShutterRequest) {
+func (in *DiscoveredDeviceSpec) DeepCopyInto(out *DiscoveredDeviceSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiscoveredDeviceSpec.
+func (in *DiscoveredDeviceSpec) DeepCopy() *DiscoveredDeviceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DiscoveredDeviceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiscoveredDeviceStatus) DeepCopyInto(out *DiscoveredDeviceStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastSeenTime != nil {
+		in, out := &in.LastSeenTime, &out.LastSeenTime
+		*out = (*in).DeepCopy()
+	}
+	if in.SuggestedRollerShutter != nil {
+		in, out := &in.SuggestedRollerShutter, &out.SuggestedRollerShutter
+		*out = new(RollerShutterSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SuggestedSwitch != nil {
+		in, out := &in.SuggestedSwitch, &out.SuggestedSwitch
+		*out = new(SwitchSpec)
+		**out = **in
+	}
+	if in.SuggestedLight != nil {
+		in, out := &in.SuggestedLight, &out.SuggestedLight
+		*out = new(LightSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiscoveredDeviceStatus.
+func (in *DiscoveredDeviceStatus) DeepCopy() *DiscoveredDeviceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DiscoveredDeviceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnergyMeter) DeepCopyInto(out *EnergyMeter) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -92,18 +251,18 @@ func (in *RollerShutterRequest) DeepCopyInto(out *RollerShutterRequest) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollerShutterRequest.
-func (in *RollerShutterRequest) DeepCopy() *RollerShutterRequest {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnergyMeter.
+func (in *EnergyMeter) DeepCopy() *EnergyMeter {
 	if in == nil {
 		return nil
 	}
-	out := new(RollerShutterRequest)
+	out := new(EnergyMeter)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RollerShutterRequest) DeepCopyObject() runtime.Object {
+func (in *EnergyMeter) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -111,31 +270,31 @@ func (in *RollerShutterRequest) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RollerShutterRequestList) DeepCopyInto(out *RollerShutterRequestList) {
+func (in *EnergyMeterList) DeepCopyInto(out *EnergyMeterList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]RollerShutterRequest, len(*in))
+		*out = make([]EnergyMeter, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollerShutterRequestList.
-func (in *RollerShutterRequestList) DeepCopy() *RollerShutterRequestList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnergyMeterList.
+func (in *EnergyMeterList) DeepCopy() *EnergyMeterList {
 	if in == nil {
 		return nil
 	}
-	out := new(RollerShutterRequestList)
+	out := new(EnergyMeterList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RollerShutterRequestList) DeepCopyObject() runtime.Object {
+func (in *EnergyMeterList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -143,23 +302,24 @@ func (in *RollerShutterRequestList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RollerShutterRequestSpec) DeepCopyInto(out *RollerShutterRequestSpec) {
+func (in *EnergyMeterSpec) DeepCopyInto(out *EnergyMeterSpec) {
 	*out = *in
-	out.RollerShutter = in.RollerShutter
+	out.Endpoint = in.Endpoint
+	out.PollInterval = in.PollInterval
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollerShutterRequestSpec.
-func (in *RollerShutterRequestSpec) DeepCopy() *RollerShutterRequestSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnergyMeterSpec.
+func (in *EnergyMeterSpec) DeepCopy() *EnergyMeterSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RollerShutterRequestSpec)
+	out := new(EnergyMeterSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RollerShutterRequestStatus) DeepCopyInto(out *RollerShutterRequestStatus) {
+func (in *EnergyMeterStatus) DeepCopyInto(out *EnergyMeterStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -168,36 +328,154 @@ func (in *RollerShutterRequestStatus) DeepCopyInto(out *RollerShutterRequestStat
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Power != nil {
+		in, out := &in.Power, &out.Power
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Voltage != nil {
+		in, out := &in.Voltage, &out.Voltage
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Current != nil {
+		in, out := &in.Current, &out.Current
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.TotalEnergy != nil {
+		in, out := &in.TotalEnergy, &out.TotalEnergy
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.DeviceTotalEnergy != nil {
+		in, out := &in.DeviceTotalEnergy, &out.DeviceTotalEnergy
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.LastCounterResetTime != nil {
+		in, out := &in.LastCounterResetTime, &out.LastCounterResetTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastReadingTime != nil {
+		in, out := &in.LastReadingTime, &out.LastReadingTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollerShutterRequestStatus.
-func (in *RollerShutterRequestStatus) DeepCopy() *RollerShutterRequestStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnergyMeterStatus.
+func (in *EnergyMeterStatus) DeepCopy() *EnergyMeterStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RollerShutterRequestStatus)
+	out := new(EnergyMeterStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RollerShutterSpec) DeepCopyInto(out *RollerShutterSpec) {
+func (in *FirmwareUpdateDeviceStatus) DeepCopyInto(out *FirmwareUpdateDeviceStatus) {
 	*out = *in
-	out.Endpoint = in.Endpoint
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollerShutterSpec.
-func (in *RollerShutterSpec) DeepCopy() *RollerShutterSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirmwareUpdateDeviceStatus.
+func (in *FirmwareUpdateDeviceStatus) DeepCopy() *FirmwareUpdateDeviceStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RollerShutterSpec)
+	out := new(FirmwareUpdateDeviceStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RollerShutterStatus) DeepCopyInto(out *RollerShutterStatus) {
+func (in *FirmwareUpdatePolicy) DeepCopyInto(out *FirmwareUpdatePolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirmwareUpdatePolicy.
+func (in *FirmwareUpdatePolicy) DeepCopy() *FirmwareUpdatePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(FirmwareUpdatePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FirmwareUpdatePolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirmwareUpdatePolicyList) DeepCopyInto(out *FirmwareUpdatePolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FirmwareUpdatePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirmwareUpdatePolicyList.
+func (in *FirmwareUpdatePolicyList) DeepCopy() *FirmwareUpdatePolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(FirmwareUpdatePolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FirmwareUpdatePolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirmwareUpdatePolicySpec) DeepCopyInto(out *FirmwareUpdatePolicySpec) {
+	*out = *in
+	in.RollerShutterSelector.DeepCopyInto(&out.RollerShutterSelector)
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		**out = **in
+	}
+	out.UpdateTimeout = in.UpdateTimeout
+	out.PollInterval = in.PollInterval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirmwareUpdatePolicySpec.
+func (in *FirmwareUpdatePolicySpec) DeepCopy() *FirmwareUpdatePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FirmwareUpdatePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirmwareUpdatePolicyStatus) DeepCopyInto(out *FirmwareUpdatePolicyStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -206,14 +484,1412 @@ func (in *RollerShutterStatus) DeepCopyInto(out *RollerShutterStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Devices != nil {
+		in, out := &in.Devices, &out.Devices
+		*out = make([]FirmwareUpdateDeviceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollerShutterStatus.
-func (in *RollerShutterStatus) DeepCopy() *RollerShutterStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirmwareUpdatePolicyStatus.
+func (in *FirmwareUpdatePolicyStatus) DeepCopy() *FirmwareUpdatePolicyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RollerShutterStatus)
+	out := new(FirmwareUpdatePolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Gate) DeepCopyInto(out *Gate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Gate.
+func (in *Gate) DeepCopy() *Gate {
+	if in == nil {
+		return nil
+	}
+	out := new(Gate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Gate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GateList) DeepCopyInto(out *GateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Gate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GateList.
+func (in *GateList) DeepCopy() *GateList {
+	if in == nil {
+		return nil
+	}
+	out := new(GateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GateRequest) DeepCopyInto(out *GateRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GateRequest.
+func (in *GateRequest) DeepCopy() *GateRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(GateRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GateRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GateRequestList) DeepCopyInto(out *GateRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GateRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GateRequestList.
+func (in *GateRequestList) DeepCopy() *GateRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(GateRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GateRequestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GateRequestSpec) DeepCopyInto(out *GateRequestSpec) {
+	*out = *in
+	out.Gate = in.Gate
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GateRequestSpec.
+func (in *GateRequestSpec) DeepCopy() *GateRequestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GateRequestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GateRequestStatus) DeepCopyInto(out *GateRequestStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GateRequestStatus.
+func (in *GateRequestStatus) DeepCopy() *GateRequestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GateRequestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GateSpec) DeepCopyInto(out *GateSpec) {
+	*out = *in
+	out.Endpoint = in.Endpoint
+	out.PulseDuration = in.PulseDuration
+	if in.OpenInput != nil {
+		in, out := &in.OpenInput, &out.OpenInput
+		*out = new(int)
+		**out = **in
+	}
+	out.TravelTime = in.TravelTime
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GateSpec.
+func (in *GateSpec) DeepCopy() *GateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GateStatus) DeepCopyInto(out *GateStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GateStatus.
+func (in *GateStatus) DeepCopy() *GateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRequestTemplate) DeepCopyInto(out *HTTPRequestTemplate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRequestTemplate.
+func (in *HTTPRequestTemplate) DeepCopy() *HTTPRequestTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRequestTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Light) DeepCopyInto(out *Light) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Light.
+func (in *Light) DeepCopy() *Light {
+	if in == nil {
+		return nil
+	}
+	out := new(Light)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Light) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LightColor) DeepCopyInto(out *LightColor) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LightColor.
+func (in *LightColor) DeepCopy() *LightColor {
+	if in == nil {
+		return nil
+	}
+	out := new(LightColor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LightList) DeepCopyInto(out *LightList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Light, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LightList.
+func (in *LightList) DeepCopy() *LightList {
+	if in == nil {
+		return nil
+	}
+	out := new(LightList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LightList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LightSpec) DeepCopyInto(out *LightSpec) {
+	*out = *in
+	out.Endpoint = in.Endpoint
+	if in.Brightness != nil {
+		in, out := &in.Brightness, &out.Brightness
+		*out = new(int)
+		**out = **in
+	}
+	if in.ColorTemperature != nil {
+		in, out := &in.ColorTemperature, &out.ColorTemperature
+		*out = new(int)
+		**out = **in
+	}
+	if in.Color != nil {
+		in, out := &in.Color, &out.Color
+		*out = new(LightColor)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LightSpec.
+func (in *LightSpec) DeepCopy() *LightSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LightSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LightStatus) DeepCopyInto(out *LightStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Color != nil {
+		in, out := &in.Color, &out.Color
+		*out = new(LightColor)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LightStatus.
+func (in *LightStatus) DeepCopy() *LightStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LightStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MQTTEndpoint) DeepCopyInto(out *MQTTEndpoint) {
+	*out = *in
+	out.BrokerSecret = in.BrokerSecret
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MQTTEndpoint.
+func (in *MQTTEndpoint) DeepCopy() *MQTTEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(MQTTEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MovementHistory) DeepCopyInto(out *MovementHistory) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MovementHistory.
+func (in *MovementHistory) DeepCopy() *MovementHistory {
+	if in == nil {
+		return nil
+	}
+	out := new(MovementHistory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MovementHistory) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MovementHistoryList) DeepCopyInto(out *MovementHistoryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MovementHistory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MovementHistoryList.
+func (in *MovementHistoryList) DeepCopy() *MovementHistoryList {
+	if in == nil {
+		return nil
+	}
+	out := new(MovementHistoryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MovementHistoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MovementHistorySpec) DeepCopyInto(out *MovementHistorySpec) {
+	*out = *in
+	out.RollerShutter = in.RollerShutter
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MovementHistorySpec.
+func (in *MovementHistorySpec) DeepCopy() *MovementHistorySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MovementHistorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MovementHistoryStatus) DeepCopyInto(out *MovementHistoryStatus) {
+	*out = *in
+	if in.Entries != nil {
+		in, out := &in.Entries, &out.Entries
+		*out = make([]MovementRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MovementHistoryStatus.
+func (in *MovementHistoryStatus) DeepCopy() *MovementHistoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MovementHistoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MovementRecord) DeepCopyInto(out *MovementRecord) {
+	*out = *in
+	if in.FromPosition != nil {
+		in, out := &in.FromPosition, &out.FromPosition
+		*out = new(int)
+		**out = **in
+	}
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MovementRecord.
+func (in *MovementRecord) DeepCopy() *MovementRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(MovementRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProtectionPolicy) DeepCopyInto(out *ProtectionPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProtectionPolicy.
+func (in *ProtectionPolicy) DeepCopy() *ProtectionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ProtectionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProtectionPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProtectionPolicyList) DeepCopyInto(out *ProtectionPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ProtectionPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProtectionPolicyList.
+func (in *ProtectionPolicyList) DeepCopy() *ProtectionPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ProtectionPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProtectionPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProtectionPolicySpec) DeepCopyInto(out *ProtectionPolicySpec) {
+	*out = *in
+	in.Source.DeepCopyInto(&out.Source)
+	out.Threshold = in.Threshold.DeepCopy()
+	out.CalmDownPeriod = in.CalmDownPeriod
+	out.PollInterval = in.PollInterval
+	in.RollerShutterSelector.DeepCopyInto(&out.RollerShutterSelector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProtectionPolicySpec.
+func (in *ProtectionPolicySpec) DeepCopy() *ProtectionPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProtectionPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProtectionPolicyStatus) DeepCopyInto(out *ProtectionPolicyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastReadingTime != nil {
+		in, out := &in.LastReadingTime, &out.LastReadingTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastTriggeredTime != nil {
+		in, out := &in.LastTriggeredTime, &out.LastTriggeredTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProtectionPolicyStatus.
+func (in *ProtectionPolicyStatus) DeepCopy() *ProtectionPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProtectionPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollerShutter) DeepCopyInto(out *RollerShutter) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollerShutter.
+func (in *RollerShutter) DeepCopy() *RollerShutter {
+	if in == nil {
+		return nil
+	}
+	out := new(RollerShutter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RollerShutter) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollerShutterEndpoint) DeepCopyInto(out *RollerShutterEndpoint) {
+	*out = *in
+	if in.MQTT != nil {
+		in, out := &in.MQTT, &out.MQTT
+		*out = new(MQTTEndpoint)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollerShutterEndpoint.
+func (in *RollerShutterEndpoint) DeepCopy() *RollerShutterEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(RollerShutterEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollerShutterFrostProtection) DeepCopyInto(out *RollerShutterFrostProtection) {
+	*out = *in
+	in.Source.DeepCopyInto(&out.Source)
+	out.Threshold = in.Threshold.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollerShutterFrostProtection.
+func (in *RollerShutterFrostProtection) DeepCopy() *RollerShutterFrostProtection {
+	if in == nil {
+		return nil
+	}
+	out := new(RollerShutterFrostProtection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollerShutterGenericHTTP) DeepCopyInto(out *RollerShutterGenericHTTP) {
+	*out = *in
+	if in.Profile != nil {
+		in, out := &in.Profile, &out.Profile
+		*out = new(RollerShutterHTTPProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProfileRef != nil {
+		in, out := &in.ProfileRef, &out.ProfileRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollerShutterGenericHTTP.
+func (in *RollerShutterGenericHTTP) DeepCopy() *RollerShutterGenericHTTP {
+	if in == nil {
+		return nil
+	}
+	out := new(RollerShutterGenericHTTP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollerShutterHTTPProfile) DeepCopyInto(out *RollerShutterHTTPProfile) {
+	*out = *in
+	out.Status = in.Status
+	out.Move = in.Move
+	if in.Stop != nil {
+		in, out := &in.Stop, &out.Stop
+		*out = new(HTTPRequestTemplate)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollerShutterHTTPProfile.
+func (in *RollerShutterHTTPProfile) DeepCopy() *RollerShutterHTTPProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(RollerShutterHTTPProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollerShutterList) DeepCopyInto(out *RollerShutterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RollerShutter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollerShutterList.
+func (in *RollerShutterList) DeepCopy() *RollerShutterList {
+	if in == nil {
+		return nil
+	}
+	out := new(RollerShutterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RollerShutterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollerShutterObstacleDetection) DeepCopyInto(out *RollerShutterObstacleDetection) {
+	*out = *in
+	if in.Power != nil {
+		in, out := &in.Power, &out.Power
+		*out = new(int)
+		**out = **in
+	}
+	if in.Delay != nil {
+		in, out := &in.Delay, &out.Delay
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollerShutterObstacleDetection.
+func (in *RollerShutterObstacleDetection) DeepCopy() *RollerShutterObstacleDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(RollerShutterObstacleDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollerShutterRequest) DeepCopyInto(out *RollerShutterRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollerShutterRequest.
+func (in *RollerShutterRequest) DeepCopy() *RollerShutterRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(RollerShutterRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RollerShutterRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollerShutterRequestList) DeepCopyInto(out *RollerShutterRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RollerShutterRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollerShutterRequestList.
+func (in *RollerShutterRequestList) DeepCopy() *RollerShutterRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(RollerShutterRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RollerShutterRequestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollerShutterRequestSpec) DeepCopyInto(out *RollerShutterRequestSpec) {
+	*out = *in
+	out.RollerShutter = in.RollerShutter
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollerShutterRequestSpec.
+func (in *RollerShutterRequestSpec) DeepCopy() *RollerShutterRequestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RollerShutterRequestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollerShutterRequestStatus) DeepCopyInto(out *RollerShutterRequestStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.StartPosition != nil {
+		in, out := &in.StartPosition, &out.StartPosition
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollerShutterRequestStatus.
+func (in *RollerShutterRequestStatus) DeepCopy() *RollerShutterRequestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RollerShutterRequestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollerShutterSettings) DeepCopyInto(out *RollerShutterSettings) {
+	*out = *in
+	if in.MaxTravelTime != nil {
+		in, out := &in.MaxTravelTime, &out.MaxTravelTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ObstacleDetection != nil {
+		in, out := &in.ObstacleDetection, &out.ObstacleDetection
+		*out = new(RollerShutterObstacleDetection)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollerShutterSettings.
+func (in *RollerShutterSettings) DeepCopy() *RollerShutterSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(RollerShutterSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollerShutterSpec) DeepCopyInto(out *RollerShutterSpec) {
+	*out = *in
+	in.Endpoint.DeepCopyInto(&out.Endpoint)
+	if in.GenericHTTP != nil {
+		in, out := &in.GenericHTTP, &out.GenericHTTP
+		*out = new(RollerShutterGenericHTTP)
+		(*in).DeepCopyInto(*out)
+	}
+	out.ManualOverrideHoldOff = in.ManualOverrideHoldOff
+	if in.FrostProtection != nil {
+		in, out := &in.FrostProtection, &out.FrostProtection
+		*out = new(RollerShutterFrostProtection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WindowContact != nil {
+		in, out := &in.WindowContact, &out.WindowContact
+		*out = new(RollerShutterWindowContact)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = new(RollerShutterSettings)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollerShutterSpec.
+func (in *RollerShutterSpec) DeepCopy() *RollerShutterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RollerShutterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollerShutterStatus) DeepCopyInto(out *RollerShutterStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastManualOverrideTime != nil {
+		in, out := &in.LastManualOverrideTime, &out.LastManualOverrideTime
+		*out = (*in).DeepCopy()
+	}
+	if in.DeviceInfo != nil {
+		in, out := &in.DeviceInfo, &out.DeviceInfo
+		*out = new(DeviceInfo)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollerShutterStatus.
+func (in *RollerShutterStatus) DeepCopy() *RollerShutterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RollerShutterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollerShutterWindowContact) DeepCopyInto(out *RollerShutterWindowContact) {
+	*out = *in
+	in.Source.DeepCopyInto(&out.Source)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollerShutterWindowContact.
+func (in *RollerShutterWindowContact) DeepCopy() *RollerShutterWindowContact {
+	if in == nil {
+		return nil
+	}
+	out := new(RollerShutterWindowContact)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Sensor) DeepCopyInto(out *Sensor) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Sensor.
+func (in *Sensor) DeepCopy() *Sensor {
+	if in == nil {
+		return nil
+	}
+	out := new(Sensor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Sensor) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SensorList) DeepCopyInto(out *SensorList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Sensor, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SensorList.
+func (in *SensorList) DeepCopy() *SensorList {
+	if in == nil {
+		return nil
+	}
+	out := new(SensorList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SensorList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SensorReading) DeepCopyInto(out *SensorReading) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SensorReading.
+func (in *SensorReading) DeepCopy() *SensorReading {
+	if in == nil {
+		return nil
+	}
+	out := new(SensorReading)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SensorReadingMapping) DeepCopyInto(out *SensorReadingMapping) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SensorReadingMapping.
+func (in *SensorReadingMapping) DeepCopy() *SensorReadingMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(SensorReadingMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SensorReference) DeepCopyInto(out *SensorReference) {
+	*out = *in
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SensorReference.
+func (in *SensorReference) DeepCopy() *SensorReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SensorReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SensorSource) DeepCopyInto(out *SensorSource) {
+	*out = *in
+	if in.SensorRef != nil {
+		in, out := &in.SensorRef, &out.SensorRef
+		*out = new(SensorReference)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SensorSource.
+func (in *SensorSource) DeepCopy() *SensorSource {
+	if in == nil {
+		return nil
+	}
+	out := new(SensorSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SensorSpec) DeepCopyInto(out *SensorSpec) {
+	*out = *in
+	out.Endpoint = in.Endpoint
+	if in.Readings != nil {
+		in, out := &in.Readings, &out.Readings
+		*out = make([]SensorReadingMapping, len(*in))
+		copy(*out, *in)
+	}
+	out.PollInterval = in.PollInterval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SensorSpec.
+func (in *SensorSpec) DeepCopy() *SensorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SensorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SensorStatus) DeepCopyInto(out *SensorStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Readings != nil {
+		in, out := &in.Readings, &out.Readings
+		*out = make([]SensorReading, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SensorStatus.
+func (in *SensorStatus) DeepCopy() *SensorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SensorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Switch) DeepCopyInto(out *Switch) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Switch.
+func (in *Switch) DeepCopy() *Switch {
+	if in == nil {
+		return nil
+	}
+	out := new(Switch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Switch) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwitchList) DeepCopyInto(out *SwitchList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Switch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwitchList.
+func (in *SwitchList) DeepCopy() *SwitchList {
+	if in == nil {
+		return nil
+	}
+	out := new(SwitchList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SwitchList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwitchSpec) DeepCopyInto(out *SwitchSpec) {
+	*out = *in
+	out.Endpoint = in.Endpoint
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwitchSpec.
+func (in *SwitchSpec) DeepCopy() *SwitchSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwitchSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwitchStatus) DeepCopyInto(out *SwitchStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwitchStatus.
+func (in *SwitchStatus) DeepCopy() *SwitchStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SwitchStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Thermostat) DeepCopyInto(out *Thermostat) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Thermostat.
+func (in *Thermostat) DeepCopy() *Thermostat {
+	if in == nil {
+		return nil
+	}
+	out := new(Thermostat)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Thermostat) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ThermostatList) DeepCopyInto(out *ThermostatList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Thermostat, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ThermostatList.
+func (in *ThermostatList) DeepCopy() *ThermostatList {
+	if in == nil {
+		return nil
+	}
+	out := new(ThermostatList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ThermostatList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ThermostatSpec) DeepCopyInto(out *ThermostatSpec) {
+	*out = *in
+	out.Endpoint = in.Endpoint
+	if in.TargetTemperature != nil {
+		in, out := &in.TargetTemperature, &out.TargetTemperature
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	out.BoostDuration = in.BoostDuration
+	out.PollInterval = in.PollInterval
+	out.RetryInterval = in.RetryInterval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ThermostatSpec.
+func (in *ThermostatSpec) DeepCopy() *ThermostatSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ThermostatSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ThermostatStatus) DeepCopyInto(out *ThermostatStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Temperature != nil {
+		in, out := &in.Temperature, &out.Temperature
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.TargetTemperature != nil {
+		in, out := &in.TargetTemperature, &out.TargetTemperature
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.LastSeenTime != nil {
+		in, out := &in.LastSeenTime, &out.LastSeenTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ThermostatStatus.
+func (in *ThermostatStatus) DeepCopy() *ThermostatStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ThermostatStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	iotapis "github.com/thetechnick/iot-operator/apis"
+	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	_ = iotapis.AddToScheme(scheme)
+}
+
+type options struct {
+	namespace string
+	limit     int
+	since     time.Duration
+}
+
+func parseFlags() *options {
+	opts := &options{}
+
+	flag.StringVar(&opts.namespace, "n", "default", "Namespace of the RollerShutters.")
+	flag.IntVar(&opts.limit, "limit", 0, "Maximum number of entries to print per RollerShutter, 0 prints all.")
+	flag.DurationVar(&opts.since, "since", 0, "Only print movements started within this duration, e.g. 24h.")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(),
+			"Usage: %s [flags] [rollershutter...]\n\n"+
+				"Prints the movement history of RollerShutters, all in the namespace if none are given.\n\n",
+			os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	return opts
+}
+
+func run() error {
+	opts := parseFlags()
+
+	cfg, err := ctrl.GetConfig()
+	if err != nil {
+		return fmt.Errorf("loading kubeconfig: %w", err)
+	}
+	c, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+
+	ctx := context.Background()
+	var histories []iotv1alpha1.MovementHistory
+	if flag.NArg() == 0 {
+		historyList := &iotv1alpha1.MovementHistoryList{}
+		if err := c.List(ctx, historyList, client.InNamespace(opts.namespace)); err != nil {
+			return fmt.Errorf("listing MovementHistories: %w", err)
+		}
+		histories = historyList.Items
+	}
+	for _, name := range flag.Args() {
+		history := iotv1alpha1.MovementHistory{}
+		if err := c.Get(ctx, client.ObjectKey{
+			Name: name, Namespace: opts.namespace,
+		}, &history); err != nil {
+			return fmt.Errorf("getting MovementHistory: %w", err)
+		}
+		histories = append(histories, history)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "ROLLERSHUTTER\tSTART\tREQUEST\tREQUESTED BY\tFROM\tTO\tTARGET\tDURATION\tOUTCOME\tREASON\tSTOP REASON")
+	for _, history := range histories {
+		for i, e := range history.Status.Entries {
+			if opts.limit > 0 && i >= opts.limit {
+				break
+			}
+			if opts.since > 0 && time.Since(e.StartTime.Time) > opts.since {
+				// entries are sorted newest first
+				break
+			}
+
+			from := "-"
+			if e.FromPosition != nil {
+				from = strconv.Itoa(*e.FromPosition)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%d\t%s\t%s\t%s\t%s\n",
+				history.Spec.RollerShutter.Name,
+				e.StartTime.Local().Format(time.RFC3339),
+				e.Request,
+				orDash(e.RequestedBy),
+				from,
+				e.ToPosition,
+				e.TargetPosition,
+				e.Duration.Round(time.Second),
+				e.Outcome,
+				orDash(e.Reason),
+				orDash(e.StopReason),
+			)
+		}
+	}
+	return w.Flush()
+}
+
+func orDash(s string) string {
+	if len(s) == 0 {
+		return "-"
+	}
+	return s
+}
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
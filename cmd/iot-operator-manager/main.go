@@ -17,8 +17,23 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	iotapis "github.com/thetechnick/iot-operator/apis"
+	"github.com/thetechnick/iot-operator/internal/clients/mqttclient"
+	"github.com/thetechnick/iot-operator/internal/clients/shellywsclient"
+	"github.com/thetechnick/iot-operator/internal/controllers/discovereddevices"
+	"github.com/thetechnick/iot-operator/internal/controllers/energymeters"
+	"github.com/thetechnick/iot-operator/internal/controllers/firmwareupdatepolicies"
+	"github.com/thetechnick/iot-operator/internal/controllers/gaterequests"
+	"github.com/thetechnick/iot-operator/internal/controllers/gates"
+	"github.com/thetechnick/iot-operator/internal/controllers/lights"
+	"github.com/thetechnick/iot-operator/internal/controllers/protectionpolicies"
 	"github.com/thetechnick/iot-operator/internal/controllers/rollershutterrequests"
 	"github.com/thetechnick/iot-operator/internal/controllers/rollershutters"
+	"github.com/thetechnick/iot-operator/internal/controllers/sensors"
+	"github.com/thetechnick/iot-operator/internal/controllers/switches"
+	"github.com/thetechnick/iot-operator/internal/controllers/thermostats"
+	"github.com/thetechnick/iot-operator/internal/discovery"
+	"github.com/thetechnick/iot-operator/internal/shellywebhook"
+	"github.com/thetechnick/iot-operator/internal/tracing"
 )
 
 var (
@@ -37,6 +52,16 @@ type options struct {
 	enableLeaderElection  bool
 	enableMetricsRecorder bool
 	probeAddr             string
+	enableMQTT            bool
+	enableWebsockets      bool
+	webhookAddr           string
+	requeueInterval       time.Duration
+	enableDiscovery       bool
+	discoveryNamespace    string
+	discoveryInterval     time.Duration
+	otlpEndpoint          string
+	otlpInsecure          bool
+	traceSampleRatio      float64
 }
 
 func parseFlags() *options {
@@ -50,20 +75,63 @@ func parseFlags() *options {
 	flag.StringVar(&opts.probeAddr, "health-probe-bind-address", ":8081",
 		"The address the probe endpoint binds to.")
 	flag.BoolVar(&opts.enableMetricsRecorder, "enable-metrics-recorder", true, "Enable recording Addon Metrics")
+	flag.BoolVar(&opts.enableMQTT, "enable-mqtt", true, "Enable communicating with devices via MQTT")
+	flag.BoolVar(&opts.enableWebsockets, "enable-websockets", true,
+		"Enable receiving notifications from Shelly Gen2 devices via WebSockets")
+	flag.StringVar(&opts.webhookAddr, "webhook-addr", "",
+		"The address the receiver for Shelly action callbacks binds to.")
+	flag.DurationVar(&opts.requeueInterval, "rollershutter-requeue-interval", 30*time.Second,
+		"Interval to poll idle RollerShutters, can be increased when devices push state changes.")
+	flag.BoolVar(&opts.enableDiscovery, "enable-discovery", false,
+		"Enable discovering devices via mDNS, requires running in the host network.")
+	flag.StringVar(&opts.discoveryNamespace, "discovery-namespace", "iot-system",
+		"The namespace to create DiscoveredDevices in.")
+	flag.DurationVar(&opts.discoveryInterval, "discovery-interval", 5*time.Minute,
+		"Interval between mDNS discovery scans.")
+	flag.StringVar(&opts.otlpEndpoint, "otlp-endpoint", "",
+		"host:port of an OTLP/HTTP collector to export traces to, tracing is disabled when empty.")
+	flag.BoolVar(&opts.otlpInsecure, "otlp-insecure", false,
+		"Export traces via plain HTTP instead of HTTPS.")
+	flag.Float64Var(&opts.traceSampleRatio, "trace-sample-ratio", 1,
+		"Fraction of traces to sample, between 0 and 1.")
 	flag.Parse()
 
 	return opts
 }
 
-func initReconcilers(mgr ctrl.Manager) error {
+func initReconcilers(mgr ctrl.Manager, opts *options) error {
 	rollerShutterReconciler := &rollershutters.RollerShutterReconciler{
 		Client:                 mgr.GetClient(),
 		Log:                    ctrl.Log.WithName("controllers").WithName("RollerShutter"),
 		Scheme:                 mgr.GetScheme(),
-		DefaultRequeueInterval: time.Second * 30,
+		Recorder:               mgr.GetEventRecorderFor("rollershutter-controller"),
+		APIReader:              mgr.GetAPIReader(),
+		DefaultRequeueInterval: opts.requeueInterval,
 		MovingRequeueInterval:  time.Second * 2,
 	}
 
+	if opts.enableMQTT {
+		rollerShutterReconciler.MQTT = mqttclient.NewManager(ctrl.Log.WithName("mqtt"))
+		if err := mgr.Add(rollerShutterReconciler.MQTT); err != nil {
+			return fmt.Errorf("unable to add MQTT manager: %w", err)
+		}
+	}
+
+	if opts.enableWebsockets {
+		rollerShutterReconciler.Websockets = shellywsclient.NewManager(ctrl.Log.WithName("websockets"))
+		if err := mgr.Add(rollerShutterReconciler.Websockets); err != nil {
+			return fmt.Errorf("unable to add websocket manager: %w", err)
+		}
+	}
+
+	if len(opts.webhookAddr) > 0 {
+		rollerShutterReconciler.Webhook = shellywebhook.NewReceiver(
+			mgr.GetClient(), ctrl.Log.WithName("webhook"), opts.webhookAddr)
+		if err := mgr.Add(rollerShutterReconciler.Webhook); err != nil {
+			return fmt.Errorf("unable to add webhook receiver: %w", err)
+		}
+	}
+
 	if err := rollerShutterReconciler.SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("unable to create RollerShutter controller: %w", err)
 	}
@@ -77,6 +145,125 @@ func initReconcilers(mgr ctrl.Manager) error {
 	if err := rollerShutterRequestReconciler.SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("unable to create RollerShutterRequest controller: %w", err)
 	}
+
+	protectionPolicyReconciler := &protectionpolicies.ProtectionPolicyReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("ProtectionPolicy"),
+		Scheme: mgr.GetScheme(),
+	}
+
+	if err := protectionPolicyReconciler.SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create ProtectionPolicy controller: %w", err)
+	}
+
+	firmwareUpdatePolicyReconciler := &firmwareupdatepolicies.FirmwareUpdatePolicyReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("FirmwareUpdatePolicy"),
+		Scheme: mgr.GetScheme(),
+	}
+
+	if err := firmwareUpdatePolicyReconciler.SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create FirmwareUpdatePolicy controller: %w", err)
+	}
+
+	switchReconciler := &switches.SwitchReconciler{
+		Client:                 mgr.GetClient(),
+		Log:                    ctrl.Log.WithName("controllers").WithName("Switch"),
+		Scheme:                 mgr.GetScheme(),
+		DefaultRequeueInterval: time.Second * 30,
+	}
+
+	if err := switchReconciler.SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Switch controller: %w", err)
+	}
+
+	lightReconciler := &lights.LightReconciler{
+		Client:                 mgr.GetClient(),
+		Log:                    ctrl.Log.WithName("controllers").WithName("Light"),
+		Scheme:                 mgr.GetScheme(),
+		DefaultRequeueInterval: time.Second * 30,
+	}
+
+	if err := lightReconciler.SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Light controller: %w", err)
+	}
+
+	sensorReconciler := &sensors.SensorReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("Sensor"),
+		Scheme: mgr.GetScheme(),
+	}
+
+	if err := sensorReconciler.SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Sensor controller: %w", err)
+	}
+
+	energyMeterReconciler := &energymeters.EnergyMeterReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("EnergyMeter"),
+		Scheme: mgr.GetScheme(),
+	}
+
+	if err := energyMeterReconciler.SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create EnergyMeter controller: %w", err)
+	}
+
+	thermostatReconciler := &thermostats.ThermostatReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("Thermostat"),
+		Scheme: mgr.GetScheme(),
+	}
+
+	if err := thermostatReconciler.SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Thermostat controller: %w", err)
+	}
+
+	gateReconciler := &gates.GateReconciler{
+		Client:                 mgr.GetClient(),
+		Log:                    ctrl.Log.WithName("controllers").WithName("Gate"),
+		Scheme:                 mgr.GetScheme(),
+		DefaultRequeueInterval: time.Second * 30,
+		MovingRequeueInterval:  time.Second * 2,
+	}
+
+	if err := gateReconciler.SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create Gate controller: %w", err)
+	}
+
+	gateRequestReconciler := &gaterequests.GateRequestReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("GateRequest"),
+		Scheme: mgr.GetScheme(),
+	}
+
+	if err := gateRequestReconciler.SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create GateRequest controller: %w", err)
+	}
+
+	discoveredDeviceReconciler := &discovereddevices.DiscoveredDeviceReconciler{
+		Client:                 mgr.GetClient(),
+		Log:                    ctrl.Log.WithName("controllers").WithName("DiscoveredDevice"),
+		Scheme:                 mgr.GetScheme(),
+		DefaultRequeueInterval: time.Minute * 5,
+	}
+
+	if err := discoveredDeviceReconciler.SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create DiscoveredDevice controller: %w", err)
+	}
+
+	if opts.enableDiscovery {
+		if opts.discoveryInterval <= 0 {
+			return fmt.Errorf("--discovery-interval must be positive, got %s", opts.discoveryInterval)
+		}
+		if err := mgr.Add(&discovery.Discovery{
+			Client:    mgr.GetClient(),
+			Log:       ctrl.Log.WithName("discovery"),
+			Namespace: opts.discoveryNamespace,
+			Interval:  opts.discoveryInterval,
+		}); err != nil {
+			return fmt.Errorf("unable to add discovery: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -119,6 +306,25 @@ func setup() error {
 
 	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
 
+	if len(opts.otlpEndpoint) > 0 {
+		shutdown, err := tracing.Setup(context.Background(), tracing.Options{
+			Endpoint:    opts.otlpEndpoint,
+			Insecure:    opts.otlpInsecure,
+			SampleRatio: opts.traceSampleRatio,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to set up tracing: %w", err)
+		}
+		defer func() {
+			// flush remaining spans
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := shutdown(ctx); err != nil {
+				setupLog.Error(err, "shutting down tracing")
+			}
+		}()
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                     scheme,
 		MetricsBindAddress:         opts.metricsAddr,
@@ -144,7 +350,7 @@ func setup() error {
 		return fmt.Errorf("unable to set up ready check: %w", err)
 	}
 
-	if err := initReconcilers(mgr); err != nil {
+	if err := initReconcilers(mgr, opts); err != nil {
 		return fmt.Errorf("init reconcilers: %w", err)
 	}
 
@@ -21,6 +21,10 @@ spec:
         image: quay.io/nico_schieder/iot-operator-manager:latest
         args:
         - --enable-leader-election
+        - --webhook-addr=:8082
+        ports:
+        - name: webhook
+          containerPort: 8082
         livenessProbe:
           httpGet:
             path: /healthz
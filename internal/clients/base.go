@@ -10,11 +10,19 @@ import (
 	"net/http"
 	"net/url"
 	"reflect"
+	"strconv"
 	"strings"
+	"time"
 
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/thetechnick/iot-operator/internal/tracing"
 	"github.com/thetechnick/iot-operator/internal/version"
 )
 
+var tracer = tracing.Tracer("github.com/thetechnick/iot-operator/internal/clients")
+
 type Client struct {
 	opts       ClientOptions
 	apiErrType reflect.Type
@@ -61,7 +69,7 @@ func (c *Client) Do(
 	path string,
 	params url.Values,
 	payload, result interface{},
-) error {
+) (err error) {
 	// Build URL
 	reqURL, err := url.Parse(c.opts.Endpoint)
 	if err != nil {
@@ -89,6 +97,19 @@ func (c *Client) Do(
 		fullUrl = reqURL.String()
 	}
 
+	// userinfo and params may contain credentials
+	spanURL := *reqURL
+	spanURL.User = nil
+	spanURL.RawQuery = ""
+	ctx, span := tracer.Start(ctx, "HTTP "+httpMethod,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPMethodKey.String(httpMethod),
+			semconv.HTTPURLKey.String(spanURL.String()),
+			semconv.NetPeerNameKey.String(reqURL.Host),
+		))
+	defer func() { tracing.EndSpan(span, err) }()
+
 	httpReq, err := http.NewRequestWithContext(ctx, httpMethod, fullUrl, resBody)
 	if err != nil {
 		return fmt.Errorf("creating http request: %w", err)
@@ -98,7 +119,16 @@ func (c *Client) Do(
 	httpReq.Header.Add("User-Agent", fmt.Sprintf("IoTOperator/%s", version.Version))
 	httpReq.Header.Add("Content-Type", "application/json")
 
+	start := time.Now()
 	httpRes, err := c.httpClient.Do(httpReq)
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(httpRes.StatusCode)
+		span.SetAttributes(semconv.HTTPStatusCodeKey.Int(httpRes.StatusCode))
+	}
+	deviceRequestDuration.
+		WithLabelValues(reqURL.Host, httpMethod, code).
+		Observe(time.Since(start).Seconds())
 	if err != nil {
 		return fmt.Errorf("executing http request: %w", err)
 	}
@@ -0,0 +1,69 @@
+package httpjsonclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"k8s.io/client-go/util/jsonpath"
+
+	"github.com/thetechnick/iot-operator/internal/clients"
+)
+
+// Client reads values from arbitrary JSON documents served via HTTP.
+type Client struct {
+	*clients.Client
+	path   string
+	params url.Values
+}
+
+// Creates a new client fetching the JSON document from the given URL.
+func NewClient(documentURL string) (*Client, error) {
+	u, err := url.Parse(documentURL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing URL: %w", err)
+	}
+
+	endpoint := url.URL{Scheme: u.Scheme, Host: u.Host, User: u.User}
+	return &Client{
+		Client: clients.NewClient(clients.WithEndpoint(endpoint.String())),
+		path:   u.Path,
+		params: u.Query(),
+	}, nil
+}
+
+// Fetches the JSON document.
+func (c *Client) Document(ctx context.Context) (doc interface{}, err error) {
+	return doc, c.Do(ctx, http.MethodGet, c.path, c.params, nil, &doc)
+}
+
+// Fetches the JSON document and returns the value selected by the JSONPath expression.
+func (c *Client) Read(ctx context.Context, jsonPath string) (string, error) {
+	doc, err := c.Document(ctx)
+	if err != nil {
+		return "", err
+	}
+	return Extract(doc, jsonPath)
+}
+
+// Returns the value selected by the JSONPath expression from the given document.
+func Extract(doc interface{}, jsonPath string) (string, error) {
+	j := jsonpath.New("reading")
+	if err := j.Parse(jsonPath); err != nil {
+		return "", fmt.Errorf("parsing JSONPath %q: %w", jsonPath, err)
+	}
+
+	var buf bytes.Buffer
+	if err := j.Execute(&buf, doc); err != nil {
+		return "", fmt.Errorf("executing JSONPath %q: %w", jsonPath, err)
+	}
+
+	value := strings.TrimSpace(buf.String())
+	if len(value) == 0 {
+		return "", fmt.Errorf("JSONPath %q selected no value", jsonPath)
+	}
+	return value, nil
+}
@@ -0,0 +1,77 @@
+package httptemplateclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"text/template"
+
+	"github.com/thetechnick/iot-operator/internal/clients"
+)
+
+// Client executes HTTP requests described by templates.
+type Client struct {
+	*clients.Client
+}
+
+func NewClient(opts clients.ClientOption) *Client {
+	return &Client{
+		Client: clients.NewClient(opts),
+	}
+}
+
+// Request describes a HTTP request relative to the client endpoint.
+// Path and Body are Go text/templates.
+type Request struct {
+	Method string
+	Path   string
+	Body   string
+}
+
+// Renders the request templates with the given data,
+// executes the request and unmarshals the JSON response into result.
+func (c *Client) Execute(
+	ctx context.Context, req Request, data interface{}, result interface{},
+) error {
+	path, err := render("path", req.Path, data)
+	if err != nil {
+		return err
+	}
+	u, err := url.Parse(path)
+	if err != nil {
+		return fmt.Errorf("parsing path %q: %w", path, err)
+	}
+
+	var payload interface{}
+	if len(req.Body) > 0 {
+		body, err := render("body", req.Body, data)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal([]byte(body), &payload); err != nil {
+			return fmt.Errorf("unmarshal json body: %w", err)
+		}
+	}
+
+	method := req.Method
+	if len(method) == 0 {
+		method = http.MethodGet
+	}
+	return c.Do(ctx, method, u.Path, u.Query(), payload, result)
+}
+
+func render(name, text string, data interface{}) (string, error) {
+	t, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("parsing %s template: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("executing %s template: %w", name, err)
+	}
+	return buf.String(), nil
+}
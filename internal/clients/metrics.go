@@ -0,0 +1,20 @@
+package clients
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var deviceRequestDuration = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name:    "iot_device_request_duration_seconds",
+		Help:    "Latency of HTTP requests to devices.",
+		Buckets: prometheus.DefBuckets,
+	},
+	// code is "error" if no response was received.
+	[]string{"host", "method", "code"},
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(deviceRequestDuration)
+}
@@ -0,0 +1,313 @@
+package mqttclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+// Timeout for connecting, subscribing and publishing.
+const operationTimeout = 10 * time.Second
+
+// Events buffered before messages stop triggering events.
+// Dropped events are caught up by regular polling.
+const eventBufferSize = 100
+
+// Broker connection details, usually loaded from a Secret.
+type Broker struct {
+	// URL of the broker, e.g. tcp://mosquitto:1883.
+	URL      string
+	Username string
+	Password string
+}
+
+// Manager shares broker connections between devices and
+// turns incoming messages into events for the subscribed objects.
+type Manager struct {
+	log    logr.Logger
+	events chan event.GenericEvent
+
+	mux         sync.Mutex
+	connections map[Broker]*Connection
+}
+
+func NewManager(log logr.Logger) *Manager {
+	return &Manager{
+		log:         log,
+		events:      make(chan event.GenericEvent, eventBufferSize),
+		connections: map[Broker]*Connection{},
+	}
+}
+
+// Events for objects that received a message on one of their subscriptions.
+// To be used with source.Channel.
+func (m *Manager) Events() <-chan event.GenericEvent {
+	return m.events
+}
+
+// Start implements manager.Runnable
+// and disconnects from all brokers when the manager stops.
+func (m *Manager) Start(ctx context.Context) error {
+	<-ctx.Done()
+
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	for broker, conn := range m.connections {
+		conn.client.Disconnect(250)
+		delete(m.connections, broker)
+	}
+	return nil
+}
+
+// Returns the connection to the given broker, connecting if needed.
+func (m *Manager) Connection(broker Broker) (*Connection, error) {
+	m.mux.Lock()
+	conn, ok := m.connections[broker]
+	m.mux.Unlock()
+	if ok {
+		return conn, nil
+	}
+
+	// connect without holding the lock,
+	// to not block other brokers while this one is slow.
+	conn, err := m.connect(broker)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	if existing, ok := m.connections[broker]; ok {
+		// lost the race against a concurrent connect
+		conn.client.Disconnect(250)
+		return existing, nil
+	}
+	m.connections[broker] = conn
+	return conn, nil
+}
+
+// Removes all subscriptions of the object,
+// except for subscriptions on the given connection,
+// and disconnects from brokers that are no longer used.
+// Called when an object moves to another broker, e.g. after a Secret rotation.
+func (m *Manager) Release(key client.ObjectKey, keep *Connection) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	for broker, conn := range m.connections {
+		if conn == keep {
+			continue
+		}
+		conn.unsubscribe(key, "")
+		if conn.idle() {
+			conn.client.Disconnect(250)
+			delete(m.connections, broker)
+		}
+	}
+}
+
+// Returns true if the object is subscribed on a connected broker,
+// so status updates are pushed.
+func (m *Manager) Connected(key client.ObjectKey) bool {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	for _, conn := range m.connections {
+		if conn.subscribed(key) && conn.client.IsConnectionOpen() {
+			return true
+		}
+	}
+	return false
+}
+
+// Removes all subscriptions of a deleted object.
+func (m *Manager) Remove(key client.ObjectKey) {
+	m.Release(key, nil)
+}
+
+func (m *Manager) connect(broker Broker) (*Connection, error) {
+	conn := &Connection{
+		log:           m.log.WithValues("broker", broker.URL),
+		events:        m.events,
+		messages:      map[string][]byte{},
+		subscriptions: map[string]map[client.ObjectKey]client.Object{},
+	}
+	opts := paho.NewClientOptions().
+		AddBroker(broker.URL).
+		SetUsername(broker.Username).
+		SetPassword(broker.Password).
+		SetClientID(fmt.Sprintf("iot-operator-%d", time.Now().UnixNano())).
+		SetAutoReconnect(true).
+		SetOnConnectHandler(conn.resubscribe)
+	conn.client = paho.NewClient(opts)
+
+	token := conn.client.Connect()
+	if !token.WaitTimeout(operationTimeout) {
+		return nil, fmt.Errorf("connecting to %s: timeout", broker.URL)
+	}
+	if err := token.Error(); err != nil {
+		return nil, fmt.Errorf("connecting to %s: %w", broker.URL, err)
+	}
+	return conn, nil
+}
+
+// Connection to a single broker.
+type Connection struct {
+	log    logr.Logger
+	client paho.Client
+	events chan<- event.GenericEvent
+
+	mux sync.RWMutex
+	// last message per topic
+	messages map[string][]byte
+	// objects to notify per topic filter
+	subscriptions map[string]map[client.ObjectKey]client.Object
+}
+
+// Subscribes the object to the topic filter,
+// replacing previous subscriptions of the object.
+// Every message matching the filter is recorded and
+// triggers an event for all subscribed objects.
+func (c *Connection) Subscribe(filter string, obj client.Object) error {
+	c.unsubscribe(client.ObjectKeyFromObject(obj), filter)
+
+	c.mux.Lock()
+	objects, subscribed := c.subscriptions[filter]
+	if !subscribed {
+		objects = map[client.ObjectKey]client.Object{}
+		c.subscriptions[filter] = objects
+	}
+	// only the key is needed to enqueue, copy to not share the object.
+	objects[client.ObjectKeyFromObject(obj)] = obj.DeepCopyObject().(client.Object)
+	c.mux.Unlock()
+
+	if subscribed {
+		return nil
+	}
+	if err := c.subscribe(filter); err != nil {
+		c.mux.Lock()
+		delete(c.subscriptions, filter)
+		c.mux.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (c *Connection) subscribe(filter string) error {
+	token := c.client.Subscribe(filter, 0, func(_ paho.Client, msg paho.Message) {
+		c.handleMessage(filter, msg)
+	})
+	if !token.WaitTimeout(operationTimeout) {
+		return fmt.Errorf("subscribing to %s: timeout", filter)
+	}
+	if err := token.Error(); err != nil {
+		return fmt.Errorf("subscribing to %s: %w", filter, err)
+	}
+	return nil
+}
+
+// Removes the object from all subscriptions except the given filter
+// and unsubscribes from filters without objects left.
+func (c *Connection) unsubscribe(key client.ObjectKey, keep string) {
+	c.mux.Lock()
+	var unused []string
+	for filter, objects := range c.subscriptions {
+		if filter == keep {
+			continue
+		}
+		delete(objects, key)
+		if len(objects) == 0 {
+			delete(c.subscriptions, filter)
+			unused = append(unused, filter)
+		}
+	}
+	c.mux.Unlock()
+
+	if len(unused) > 0 {
+		// not waiting for the broker,
+		// messages on filters without objects don't trigger events.
+		c.client.Unsubscribe(unused...)
+	}
+}
+
+// Returns true if the object is subscribed to any filter.
+func (c *Connection) subscribed(key client.ObjectKey) bool {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	for _, objects := range c.subscriptions {
+		if _, ok := objects[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if no objects are subscribed.
+func (c *Connection) idle() bool {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	return len(c.subscriptions) == 0
+}
+
+// Subscriptions are lost when reconnecting with a clean session.
+func (c *Connection) resubscribe(_ paho.Client) {
+	c.mux.RLock()
+	filters := make([]string, 0, len(c.subscriptions))
+	for filter := range c.subscriptions {
+		filters = append(filters, filter)
+	}
+	c.mux.RUnlock()
+
+	for _, filter := range filters {
+		go func(filter string) {
+			if err := c.subscribe(filter); err != nil {
+				c.log.Error(err, "resubscribing")
+			}
+		}(filter)
+	}
+}
+
+func (c *Connection) handleMessage(filter string, msg paho.Message) {
+	c.mux.Lock()
+	c.messages[msg.Topic()] = msg.Payload()
+	objects := make([]client.Object, 0, len(c.subscriptions[filter]))
+	for _, obj := range c.subscriptions[filter] {
+		objects = append(objects, obj)
+	}
+	c.mux.Unlock()
+
+	for _, obj := range objects {
+		// never block the paho message handler
+		select {
+		case c.events <- event.GenericEvent{Object: obj}:
+		default:
+			c.log.V(1).Info("event buffer full, dropping event",
+				"object", client.ObjectKeyFromObject(obj))
+		}
+	}
+}
+
+// Returns the last message received on the topic.
+func (c *Connection) Message(topic string) ([]byte, bool) {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	msg, ok := c.messages[topic]
+	return msg, ok
+}
+
+// Publishes the payload to the topic.
+func (c *Connection) Publish(topic string, payload string) error {
+	token := c.client.Publish(topic, 0, false, payload)
+	if !token.WaitTimeout(operationTimeout) {
+		return fmt.Errorf("publishing to %s: timeout", topic)
+	}
+	if err := token.Error(); err != nil {
+		return fmt.Errorf("publishing to %s: %w", topic, err)
+	}
+	return nil
+}
@@ -38,6 +38,52 @@ func (c *Client) ToPosition(
 	)
 }
 
+func (c *Client) Stop(
+	ctx context.Context,
+) (res Status, err error) {
+	return res, c.Do(
+		ctx, http.MethodGet, "roller/0", url.Values{
+			"go": []string{"stop"},
+		}, nil, &res,
+	)
+}
+
+// Returns the status of the whole device.
+func (c *Client) DeviceStatus(
+	ctx context.Context,
+) (res DeviceStatus, err error) {
+	return res, c.Do(
+		ctx, http.MethodGet, "status", nil, nil, &res)
+}
+
+// Triggers an update to the latest firmware.
+func (c *Client) OTAUpdate(
+	ctx context.Context,
+) error {
+	return c.Do(
+		ctx, http.MethodGet, "ota", url.Values{
+			"update": []string{"true"},
+		}, nil, nil,
+	)
+}
+
+// Returns the configuration of the roller.
+func (c *Client) RollerSettings(
+	ctx context.Context,
+) (res RollerSettings, err error) {
+	return res, c.Do(
+		ctx, http.MethodGet, "settings/roller/0", nil, nil, &res)
+}
+
+// Changes the configuration of the roller.
+func (c *Client) SetRollerSettings(
+	ctx context.Context,
+	params RollerSettingsParams,
+) (res RollerSettings, err error) {
+	return res, c.Do(
+		ctx, http.MethodGet, "settings/roller/0", params.values(), nil, &res)
+}
+
 type Status struct {
 	State           State      `json:"state"`
 	Power           float64    `json:"power"`
@@ -74,3 +120,77 @@ const (
 	DirectionOpen  Direction = "open"
 	DirectionClose Direction = "close"
 )
+
+type DeviceStatus struct {
+	WiFiSta struct {
+		Connected bool `json:"connected"`
+		// Signal strength in dBm.
+		RSSI int `json:"rssi"`
+	} `json:"wifi_sta"`
+	Update struct {
+		HasUpdate  bool   `json:"has_update"`
+		NewVersion string `json:"new_version"`
+		OldVersion string `json:"old_version"`
+	} `json:"update"`
+	// Uptime in seconds.
+	Uptime int64 `json:"uptime"`
+	// Internal temperature in degrees Celsius.
+	Temperature     float64 `json:"temperature"`
+	OverTemperature bool    `json:"overtemperature"`
+}
+
+type RollerSettings struct {
+	// Maximum motor run time in seconds.
+	MaxTime float64 `json:"maxtime"`
+	// "stop", "open", "close" or "switch".
+	DefaultState string `json:"default_state"`
+	// "openclose" or "onebutton".
+	InputMode string `json:"input_mode"`
+	// "disabled", "while_opening", "while_closing" or "while_moving".
+	ObstacleMode string `json:"obstacle_mode"`
+	// "stop" or "reverse".
+	ObstacleAction string `json:"obstacle_action"`
+	// Power threshold in Watts.
+	ObstaclePower int `json:"obstacle_power"`
+	// Delay after motor start in seconds.
+	ObstacleDelay int `json:"obstacle_delay"`
+}
+
+// Settings to change, nil/empty values are left untouched.
+type RollerSettingsParams struct {
+	MaxTime        *float64
+	DefaultState   string
+	InputMode      string
+	ObstacleMode   string
+	ObstacleAction string
+	ObstaclePower  *int
+	ObstacleDelay  *int
+}
+
+func (p RollerSettingsParams) values() url.Values {
+	v := url.Values{}
+	if p.MaxTime != nil {
+		v.Set("maxtime", strconv.FormatFloat(*p.MaxTime, 'f', -1, 64))
+	}
+	setString(v, "default_state", p.DefaultState)
+	setString(v, "input_mode", p.InputMode)
+	setString(v, "obstacle_mode", p.ObstacleMode)
+	setString(v, "obstacle_action", p.ObstacleAction)
+	setInt(v, "obstacle_power", p.ObstaclePower)
+	setInt(v, "obstacle_delay", p.ObstacleDelay)
+	return v
+}
+
+func setString(v url.Values, key string, s string) {
+	if len(s) == 0 {
+		return
+	}
+	v.Set(key, s)
+}
+
+func setInt(v url.Values, key string, i *int) {
+	if i == nil {
+		return
+	}
+	v.Set(key, strconv.Itoa(*i))
+}
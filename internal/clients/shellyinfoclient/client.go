@@ -0,0 +1,79 @@
+package shellyinfoclient
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/thetechnick/iot-operator/internal/clients"
+)
+
+// Client for the identity endpoint shared by Shelly Gen1 and Gen2 devices.
+type Client struct {
+	*clients.Client
+}
+
+func NewClient(opts clients.ClientOption) *Client {
+	return &Client{
+		Client: clients.NewClient(opts),
+	}
+}
+
+func (c *Client) Info(
+	ctx context.Context,
+) (res Info, err error) {
+	return res, c.Do(
+		ctx, http.MethodGet, "shelly", nil, nil, &res)
+}
+
+// Returns the device settings of Gen1 devices.
+func (c *Client) Settings(
+	ctx context.Context,
+) (res Settings, err error) {
+	return res, c.Do(
+		ctx, http.MethodGet, "settings", nil, nil, &res)
+}
+
+// Device identity as reported by /shelly.
+// Gen1 and Gen2 devices report different fields.
+type Info struct {
+	// MAC address without separators, e.g. A4CF12C45BBE.
+	MAC string `json:"mac"`
+
+	// Gen1
+	// Device type, e.g. SHSW-25.
+	Type string `json:"type,omitempty"`
+	// Firmware version.
+	FW string `json:"fw,omitempty"`
+	// Number of relay outputs.
+	NumOutputs int `json:"num_outputs,omitempty"`
+	// Number of roller outputs.
+	NumRollers int `json:"num_rollers,omitempty"`
+	// Device mode, e.g. roller or relay.
+	Mode string `json:"mode,omitempty"`
+
+	// Gen2
+	// Generation of the device, 0 for Gen1.
+	Gen int `json:"gen,omitempty"`
+	// Device ID, e.g. shellyplus2pm-a8032ab12345.
+	ID string `json:"id,omitempty"`
+	// Model ID, e.g. SNSW-102P16EU.
+	Model string `json:"model,omitempty"`
+	// Firmware version.
+	Ver string `json:"ver,omitempty"`
+	// Device profile, e.g. cover or switch.
+	Profile string `json:"profile,omitempty"`
+}
+
+// Subset of the Gen1 /settings response.
+type Settings struct {
+	Device SettingsDevice `json:"device"`
+}
+
+type SettingsDevice struct {
+	// Device type, e.g. SHSW-25.
+	Type string `json:"type"`
+	// MAC address without separators.
+	MAC string `json:"mac"`
+	// Hostname and device ID, e.g. shellyswitch25-C45BBE.
+	Hostname string `json:"hostname"`
+}
@@ -0,0 +1,137 @@
+package shellylightclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/thetechnick/iot-operator/internal/clients"
+)
+
+// Client for Shelly Gen1 light devices, e.g. Shelly Dimmer 2, Duo and RGBW2.
+type Client struct {
+	*clients.Client
+}
+
+func NewClient(opts clients.ClientOption) *Client {
+	return &Client{
+		Client: clients.NewClient(opts),
+	}
+}
+
+// Status of a white light channel.
+func (c *Client) Light(
+	ctx context.Context,
+	channel int,
+) (res LightStatus, err error) {
+	return res, c.Do(
+		ctx, http.MethodGet, "light/"+strconv.Itoa(channel), nil, nil, &res)
+}
+
+// Changes settings of a white light channel.
+func (c *Client) SetLight(
+	ctx context.Context,
+	channel int,
+	params LightParams,
+) (res LightStatus, err error) {
+	return res, c.Do(
+		ctx, http.MethodGet, "light/"+strconv.Itoa(channel), params.values(), nil, &res)
+}
+
+// Status of a color light channel.
+func (c *Client) Color(
+	ctx context.Context,
+	channel int,
+) (res ColorStatus, err error) {
+	return res, c.Do(
+		ctx, http.MethodGet, "color/"+strconv.Itoa(channel), nil, nil, &res)
+}
+
+// Changes settings of a color light channel.
+func (c *Client) SetColor(
+	ctx context.Context,
+	channel int,
+	params ColorParams,
+) (res ColorStatus, err error) {
+	return res, c.Do(
+		ctx, http.MethodGet, "color/"+strconv.Itoa(channel), params.values(), nil, &res)
+}
+
+type LightStatus struct {
+	IsOn   bool   `json:"ison"`
+	Source string `json:"source"`
+	// Brightness in percent.
+	Brightness int `json:"brightness"`
+	// Color temperature in Kelvin.
+	// Only reported by devices supporting color temperatures.
+	Temp int `json:"temp"`
+}
+
+// Parameters to change, nil values are left untouched.
+type LightParams struct {
+	On         *bool
+	Brightness *int
+	Temp       *int
+}
+
+func (p LightParams) values() url.Values {
+	v := url.Values{}
+	setTurn(v, p.On)
+	setInt(v, "brightness", p.Brightness)
+	setInt(v, "temp", p.Temp)
+	return v
+}
+
+type ColorStatus struct {
+	IsOn  bool   `json:"ison"`
+	Mode  string `json:"mode"`
+	Red   int    `json:"red"`
+	Green int    `json:"green"`
+	Blue  int    `json:"blue"`
+	White int    `json:"white"`
+	// Brightness in percent.
+	Gain int `json:"gain"`
+	// Power consumption in Watts.
+	Power     float64 `json:"power"`
+	Overpower bool    `json:"overpower"`
+}
+
+// Parameters to change, nil values are left untouched.
+type ColorParams struct {
+	On    *bool
+	Red   *int
+	Green *int
+	Blue  *int
+	White *int
+	Gain  *int
+}
+
+func (p ColorParams) values() url.Values {
+	v := url.Values{}
+	setTurn(v, p.On)
+	setInt(v, "red", p.Red)
+	setInt(v, "green", p.Green)
+	setInt(v, "blue", p.Blue)
+	setInt(v, "white", p.White)
+	setInt(v, "gain", p.Gain)
+	return v
+}
+
+func setTurn(v url.Values, on *bool) {
+	if on == nil {
+		return
+	}
+	if *on {
+		v.Set("turn", "on")
+	} else {
+		v.Set("turn", "off")
+	}
+}
+
+func setInt(v url.Values, key string, i *int) {
+	if i == nil {
+		return
+	}
+	v.Set(key, strconv.Itoa(*i))
+}
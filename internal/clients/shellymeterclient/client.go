@@ -0,0 +1,77 @@
+package shellymeterclient
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/thetechnick/iot-operator/internal/clients"
+)
+
+// Client for power meters of Shelly Gen1 devices,
+// e.g. Shelly EM, 3EM and the per-channel meters of the Shelly 2.5.
+type Client struct {
+	*clients.Client
+}
+
+func NewClient(opts clients.ClientOption) *Client {
+	return &Client{
+		Client: clients.NewClient(opts),
+	}
+}
+
+func (c *Client) Status(
+	ctx context.Context,
+) (res Status, err error) {
+	return res, c.Do(
+		ctx, http.MethodGet, "status", nil, nil, &res)
+}
+
+// Energy meter of Shelly EM and 3EM devices.
+func (c *Client) EMeter(
+	ctx context.Context,
+	channel int,
+) (res EMeterStatus, err error) {
+	return res, c.Do(
+		ctx, http.MethodGet, "emeter/"+strconv.Itoa(channel), nil, nil, &res)
+}
+
+// Power meter of relay and roller devices like the Shelly 2.5.
+func (c *Client) Meter(
+	ctx context.Context,
+	channel int,
+) (res MeterStatus, err error) {
+	return res, c.Do(
+		ctx, http.MethodGet, "meter/"+strconv.Itoa(channel), nil, nil, &res)
+}
+
+type Status struct {
+	// Supply voltage in Volts.
+	// Only reported by some devices like the Shelly 2.5.
+	Voltage *float64 `json:"voltage"`
+}
+
+type EMeterStatus struct {
+	// Active power in Watts.
+	Power float64 `json:"power"`
+	// Voltage in Volts.
+	Voltage float64 `json:"voltage"`
+	// Current in Amperes.
+	// Only reported by the Shelly 3EM.
+	Current *float64 `json:"current"`
+	IsValid bool     `json:"is_valid"`
+	// Total consumed energy in Watt-hours.
+	Total float64 `json:"total"`
+	// Total returned energy in Watt-hours.
+	TotalReturned float64 `json:"total_returned"`
+}
+
+type MeterStatus struct {
+	// Active power in Watts.
+	Power     float64 `json:"power"`
+	Overpower float64 `json:"overpower"`
+	IsValid   bool    `json:"is_valid"`
+	// Total consumed energy in Watt-minutes.
+	// Reset when the device reboots.
+	Total float64 `json:"total"`
+}
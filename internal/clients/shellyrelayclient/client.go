@@ -0,0 +1,85 @@
+package shellyrelayclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/thetechnick/iot-operator/internal/clients"
+)
+
+// Client for Shelly Gen1 relay devices, e.g. Shelly 1, 1PM and 2.5 in relay mode.
+type Client struct {
+	*clients.Client
+}
+
+func NewClient(opts clients.ClientOption) *Client {
+	return &Client{
+		Client: clients.NewClient(opts),
+	}
+}
+
+func (c *Client) Status(
+	ctx context.Context,
+) (res Status, err error) {
+	return res, c.Do(
+		ctx, http.MethodGet, "status", nil, nil, &res)
+}
+
+func (c *Client) Turn(
+	ctx context.Context,
+	channel int,
+	on bool,
+) (res RelayStatus, err error) {
+	turn := "off"
+	if on {
+		turn = "on"
+	}
+	return res, c.Do(
+		ctx, http.MethodGet, "relay/"+strconv.Itoa(channel), url.Values{
+			"turn": []string{turn},
+		}, nil, &res,
+	)
+}
+
+// Turns the relay on for the given duration.
+func (c *Client) Pulse(
+	ctx context.Context,
+	channel int,
+	duration time.Duration,
+) (res RelayStatus, err error) {
+	return res, c.Do(
+		ctx, http.MethodGet, "relay/"+strconv.Itoa(channel), url.Values{
+			"turn":  []string{"on"},
+			"timer": []string{strconv.FormatFloat(duration.Seconds(), 'f', -1, 64)},
+		}, nil, &res,
+	)
+}
+
+type Status struct {
+	Relays []RelayStatus `json:"relays"`
+	Meters []MeterStatus `json:"meters"`
+	Inputs []InputStatus `json:"inputs"`
+}
+
+type RelayStatus struct {
+	IsOn      bool   `json:"ison"`
+	HasTimer  bool   `json:"has_timer"`
+	Overpower bool   `json:"overpower"`
+	Source    string `json:"source"`
+}
+
+type InputStatus struct {
+	// 1 if the input is active.
+	Input int `json:"input"`
+}
+
+type MeterStatus struct {
+	// Current power consumption in Watts.
+	Power   float64 `json:"power"`
+	IsValid bool    `json:"is_valid"`
+	// Total energy consumed in Watt-minute.
+	Total int `json:"total"`
+}
@@ -0,0 +1,236 @@
+package shellyrpcclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/thetechnick/iot-operator/internal/clients"
+)
+
+// Client for the RPC API of Shelly Gen2 devices, e.g. Shelly Plus 1.
+type Client struct {
+	*clients.Client
+}
+
+func NewClient(opts clients.ClientOption) *Client {
+	return &Client{
+		Client: clients.NewClient(opts, clients.WithAPIErrType{APIError: APIError{}}),
+	}
+}
+
+func (c *Client) SwitchGetStatus(
+	ctx context.Context,
+	id int,
+) (res SwitchStatus, err error) {
+	return res, c.Do(
+		ctx, http.MethodGet, "rpc/Switch.GetStatus", url.Values{
+			"id": []string{strconv.Itoa(id)},
+		}, nil, &res,
+	)
+}
+
+func (c *Client) SwitchSet(
+	ctx context.Context,
+	id int,
+	on bool,
+) (res SwitchSetResult, err error) {
+	return res, c.Do(
+		ctx, http.MethodGet, "rpc/Switch.Set", url.Values{
+			"id": []string{strconv.Itoa(id)},
+			"on": []string{strconv.FormatBool(on)},
+		}, nil, &res,
+	)
+}
+
+// Turns the switch on for the given duration.
+func (c *Client) SwitchPulse(
+	ctx context.Context,
+	id int,
+	duration time.Duration,
+) (res SwitchSetResult, err error) {
+	return res, c.Do(
+		ctx, http.MethodGet, "rpc/Switch.Set", url.Values{
+			"id":           []string{strconv.Itoa(id)},
+			"on":           []string{"true"},
+			"toggle_after": []string{strconv.FormatFloat(duration.Seconds(), 'f', -1, 64)},
+		}, nil, &res,
+	)
+}
+
+func (c *Client) InputGetStatus(
+	ctx context.Context,
+	id int,
+) (res InputStatus, err error) {
+	return res, c.Do(
+		ctx, http.MethodGet, "rpc/Input.GetStatus", url.Values{
+			"id": []string{strconv.Itoa(id)},
+		}, nil, &res,
+	)
+}
+
+// Returns the status of the whole device.
+func (c *Client) ShellyGetStatus(
+	ctx context.Context,
+) (res DeviceStatus, err error) {
+	return res, c.Do(
+		ctx, http.MethodGet, "rpc/Shelly.GetStatus", nil, nil, &res)
+}
+
+// Triggers an update to the latest stable firmware.
+func (c *Client) ShellyUpdate(
+	ctx context.Context,
+) error {
+	return c.Do(
+		ctx, http.MethodGet, "rpc/Shelly.Update", url.Values{
+			"stage": []string{"stable"},
+		}, nil, nil,
+	)
+}
+
+func (c *Client) CoverGetStatus(
+	ctx context.Context,
+	id int,
+) (res CoverStatus, err error) {
+	return res, c.Do(
+		ctx, http.MethodGet, "rpc/Cover.GetStatus", url.Values{
+			"id": []string{strconv.Itoa(id)},
+		}, nil, &res,
+	)
+}
+
+func (c *Client) CoverGoToPosition(
+	ctx context.Context,
+	id int,
+	position int,
+) error {
+	return c.Do(
+		ctx, http.MethodGet, "rpc/Cover.GoToPosition", url.Values{
+			"id":  []string{strconv.Itoa(id)},
+			"pos": []string{strconv.Itoa(position)},
+		}, nil, nil,
+	)
+}
+
+func (c *Client) CoverStop(
+	ctx context.Context,
+	id int,
+) error {
+	return c.Do(
+		ctx, http.MethodGet, "rpc/Cover.Stop", url.Values{
+			"id": []string{strconv.Itoa(id)},
+		}, nil, nil,
+	)
+}
+
+func (c *Client) TemperatureGetStatus(
+	ctx context.Context,
+	id int,
+) (res TemperatureStatus, err error) {
+	return res, c.Do(
+		ctx, http.MethodGet, "rpc/Temperature.GetStatus", url.Values{
+			"id": []string{strconv.Itoa(id)},
+		}, nil, &res,
+	)
+}
+
+func (c *Client) HumidityGetStatus(
+	ctx context.Context,
+	id int,
+) (res HumidityStatus, err error) {
+	return res, c.Do(
+		ctx, http.MethodGet, "rpc/Humidity.GetStatus", url.Values{
+			"id": []string{strconv.Itoa(id)},
+		}, nil, &res,
+	)
+}
+
+// APIError is returned by the device, when a RPC call fails.
+type APIError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e APIError) Error() string {
+	return fmt.Sprintf("RPC error %d: %s", e.Code, e.Message)
+}
+
+type SwitchStatus struct {
+	ID     int    `json:"id"`
+	Source string `json:"source"`
+	Output bool   `json:"output"`
+	// Active power in Watts.
+	APower float64 `json:"apower"`
+	// Voltage in Volts.
+	Voltage float64 `json:"voltage"`
+	// Current in Amperes.
+	Current float64 `json:"current"`
+}
+
+type SwitchSetResult struct {
+	WasOn bool `json:"was_on"`
+}
+
+type DeviceStatus struct {
+	Sys struct {
+		// Uptime in seconds.
+		Uptime           int64 `json:"uptime"`
+		AvailableUpdates struct {
+			Stable *struct {
+				Version string `json:"version"`
+			} `json:"stable,omitempty"`
+		} `json:"available_updates"`
+	} `json:"sys"`
+	WiFi struct {
+		// Signal strength in dBm.
+		RSSI int `json:"rssi"`
+	} `json:"wifi"`
+	Cover0 *CoverStatus `json:"cover:0,omitempty"`
+}
+
+type CoverStatus struct {
+	ID    int        `json:"id"`
+	State CoverState `json:"state"`
+	// Position in percentage open.
+	// nil if the cover is not calibrated.
+	CurrentPos *int `json:"current_pos"`
+	// Active power in Watts.
+	APower      float64           `json:"apower"`
+	Temperature TemperatureStatus `json:"temperature"`
+	// Error conditions, e.g. overtemp or overpower.
+	Errors []string `json:"errors,omitempty"`
+}
+
+type CoverState string
+
+const (
+	CoverStateOpen        CoverState = "open"
+	CoverStateClosed      CoverState = "closed"
+	CoverStateOpening     CoverState = "opening"
+	CoverStateClosing     CoverState = "closing"
+	CoverStateStopped     CoverState = "stopped"
+	CoverStateCalibrating CoverState = "calibrating"
+)
+
+type InputStatus struct {
+	ID int `json:"id"`
+	// True if the input is active.
+	State bool `json:"state"`
+}
+
+type TemperatureStatus struct {
+	ID int `json:"id"`
+	// Temperature in degrees Celsius.
+	// nil if the sensor is not connected.
+	TC *float64 `json:"tC"`
+}
+
+type HumidityStatus struct {
+	ID int `json:"id"`
+	// Relative humidity in percent.
+	// nil if the sensor is not connected.
+	RH *float64 `json:"rh"`
+}
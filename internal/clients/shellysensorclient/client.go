@@ -0,0 +1,67 @@
+package shellysensorclient
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/thetechnick/iot-operator/internal/clients"
+)
+
+// Client for Shelly Gen1 sensor devices, e.g. Shelly H&T and Door/Window 2.
+// Battery powered devices are only reachable while awake.
+type Client struct {
+	*clients.Client
+}
+
+func NewClient(opts clients.ClientOption) *Client {
+	return &Client{
+		Client: clients.NewClient(opts),
+	}
+}
+
+func (c *Client) Status(
+	ctx context.Context,
+) (res Status, err error) {
+	return res, c.Do(
+		ctx, http.MethodGet, "status", nil, nil, &res)
+}
+
+// Sensor values, not all devices report all values.
+type Status struct {
+	Temperature *Temperature `json:"tmp"`
+	Humidity    *Humidity    `json:"hum"`
+	Battery     *Battery     `json:"bat"`
+	Lux         *Lux         `json:"lux"`
+	Sensor      *Sensor      `json:"sensor"`
+}
+
+type Temperature struct {
+	// Temperature in degrees Celsius.
+	Celsius float64 `json:"tC"`
+	IsValid bool    `json:"is_valid"`
+}
+
+type Humidity struct {
+	// Relative humidity in percent.
+	Value   float64 `json:"value"`
+	IsValid bool    `json:"is_valid"`
+}
+
+type Battery struct {
+	// Battery level in percent.
+	Value int `json:"value"`
+	// Battery voltage in Volts.
+	Voltage float64 `json:"voltage"`
+}
+
+type Lux struct {
+	// Illuminance in Lux.
+	Value   float64 `json:"value"`
+	IsValid bool    `json:"is_valid"`
+}
+
+type Sensor struct {
+	// Contact state, "open" or "close".
+	State   string `json:"state"`
+	IsValid bool   `json:"is_valid"`
+}
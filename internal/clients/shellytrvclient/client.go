@@ -0,0 +1,109 @@
+package shellytrvclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/thetechnick/iot-operator/internal/clients"
+)
+
+// Client for Shelly TRV radiator valves.
+// The device is battery powered and only reachable while awake.
+type Client struct {
+	*clients.Client
+}
+
+func NewClient(opts clients.ClientOption) *Client {
+	return &Client{
+		Client: clients.NewClient(opts),
+	}
+}
+
+func (c *Client) Status(
+	ctx context.Context,
+) (res Status, err error) {
+	return res, c.Do(
+		ctx, http.MethodGet, "status", nil, nil, &res)
+}
+
+// Changes thermostat settings.
+func (c *Client) SetThermostat(
+	ctx context.Context,
+	channel int,
+	params ThermostatParams,
+) (res ThermostatStatus, err error) {
+	return res, c.Do(
+		ctx, http.MethodGet, "thermostat/"+strconv.Itoa(channel), params.values(), nil, &res)
+}
+
+type Status struct {
+	Thermostats []ThermostatStatus `json:"thermostats"`
+	Battery     Battery            `json:"bat"`
+	Charger     bool               `json:"charger"`
+}
+
+type ThermostatStatus struct {
+	// Valve position in percent.
+	Position        float64     `json:"pos"`
+	Target          Target      `json:"target_t"`
+	Temperature     Temperature `json:"tmp"`
+	Schedule        bool        `json:"schedule"`
+	ScheduleProfile int         `json:"schedule_profile"`
+	BoostMinutes    int         `json:"boost_minutes"`
+	WindowOpen      bool        `json:"window_open"`
+}
+
+type Target struct {
+	Enabled bool `json:"enabled"`
+	// Target temperature.
+	Value float64 `json:"value"`
+	Units string  `json:"units"`
+}
+
+type Temperature struct {
+	// Measured temperature.
+	Value   float64 `json:"value"`
+	Units   string  `json:"units"`
+	IsValid bool    `json:"is_valid"`
+}
+
+type Battery struct {
+	// Battery level in percent.
+	Value int `json:"value"`
+	// Battery voltage in Volts.
+	Voltage float64 `json:"voltage"`
+}
+
+// Parameters to change, nil values are left untouched.
+type ThermostatParams struct {
+	// Target temperature in degrees Celsius.
+	Target        *float64
+	TargetEnabled *bool
+	// Valve position in percent,
+	// only effective while target temperature control is disabled.
+	Position     *int
+	Schedule     *bool
+	BoostMinutes *int
+}
+
+func (p ThermostatParams) values() url.Values {
+	v := url.Values{}
+	if p.Target != nil {
+		v.Set("target_t", strconv.FormatFloat(*p.Target, 'f', -1, 64))
+	}
+	if p.TargetEnabled != nil {
+		v.Set("target_t_enabled", strconv.FormatBool(*p.TargetEnabled))
+	}
+	if p.Position != nil {
+		v.Set("pos", strconv.Itoa(*p.Position))
+	}
+	if p.Schedule != nil {
+		v.Set("schedule", strconv.FormatBool(*p.Schedule))
+	}
+	if p.BoostMinutes != nil {
+		v.Set("boost_minutes", strconv.Itoa(*p.BoostMinutes))
+	}
+	return v
+}
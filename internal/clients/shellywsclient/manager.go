@@ -0,0 +1,225 @@
+package shellywsclient
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/websocket"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+const (
+	// Source identifying us towards the device,
+	// Gen2 devices only send notifications to clients with a source.
+	rpcSource = "iot-operator"
+
+	minBackoff = time.Second
+	maxBackoff = 5 * time.Minute
+	// Connections lasting longer than this reset the backoff.
+	stableConnection = time.Minute
+)
+
+// Manager maintains one WebSocket per Shelly Gen2 device
+// and turns status notifications into events for the owning objects.
+type Manager struct {
+	log    logr.Logger
+	ctx    context.Context
+	cancel context.CancelFunc
+	events chan event.GenericEvent
+
+	mux         sync.Mutex
+	connections map[client.ObjectKey]*connection
+}
+
+type connection struct {
+	endpoint string
+	cancel   context.CancelFunc
+	// true while the WebSocket is established, guarded by Manager.mux.
+	connected bool
+}
+
+func NewManager(log logr.Logger) *Manager {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Manager{
+		log:         log,
+		ctx:         ctx,
+		cancel:      cancel,
+		events:      make(chan event.GenericEvent),
+		connections: map[client.ObjectKey]*connection{},
+	}
+}
+
+// Events for objects whose device sent a notification.
+// To be used with source.Channel.
+func (m *Manager) Events() <-chan event.GenericEvent {
+	return m.events
+}
+
+// Start implements manager.Runnable
+// and closes all connections when the manager stops.
+func (m *Manager) Start(ctx context.Context) error {
+	<-ctx.Done()
+	m.cancel()
+	return nil
+}
+
+// Ensures a WebSocket to the device at the given HTTP endpoint
+// is maintained for the object. Changing the endpoint reconnects.
+func (m *Manager) Ensure(obj client.Object, endpoint string) {
+	key := client.ObjectKeyFromObject(obj)
+
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	if conn, ok := m.connections[key]; ok {
+		if conn.endpoint == endpoint {
+			return
+		}
+		conn.cancel()
+	}
+
+	ctx, cancel := context.WithCancel(m.ctx)
+	conn := &connection{
+		endpoint: endpoint,
+		cancel:   cancel,
+	}
+	m.connections[key] = conn
+	// only the key is needed to enqueue, copy to not share the object.
+	go m.run(ctx, conn, obj.DeepCopyObject().(client.Object))
+}
+
+// Returns true if the WebSocket for the object is established,
+// so notifications are received.
+func (m *Manager) Connected(key client.ObjectKey) bool {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	conn, ok := m.connections[key]
+	return ok && conn.connected
+}
+
+func (m *Manager) setConnected(conn *connection, connected bool) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	conn.connected = connected
+}
+
+// Closes the WebSocket maintained for the object.
+func (m *Manager) Remove(key client.ObjectKey) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	if conn, ok := m.connections[key]; ok {
+		conn.cancel()
+		delete(m.connections, key)
+	}
+}
+
+// Keeps the WebSocket connected, reconnecting with backoff.
+func (m *Manager) run(ctx context.Context, c *connection, obj client.Object) {
+	log := m.log.WithValues(
+		"object", client.ObjectKeyFromObject(obj).String(), "endpoint", c.endpoint)
+
+	backoff := minBackoff
+	for {
+		connectedAt := time.Now()
+		err := m.listen(ctx, c, obj)
+		if ctx.Err() != nil {
+			return
+		}
+		if time.Since(connectedAt) > stableConnection {
+			backoff = minBackoff
+		}
+		log.Error(err, "websocket disconnected", "retryAfter", backoff.String())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// Generic RPC frame, only the fields needed to detect notifications.
+type frame struct {
+	ID     int    `json:"id,omitempty"`
+	Src    string `json:"src,omitempty"`
+	Method string `json:"method,omitempty"`
+}
+
+// Connects and emits an event for every notification until the connection fails.
+func (m *Manager) listen(ctx context.Context, c *connection, obj client.Object) error {
+	wsURL, err := websocketURL(c.endpoint)
+	if err != nil {
+		return err
+	}
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, wsURL, nil)
+	if err != nil {
+		return fmt.Errorf("connecting to %s: %w", wsURL, err)
+	}
+	defer conn.Close()
+	go func() {
+		// unblock ReadJSON when done
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	// The device starts sending notifications after the first request.
+	if err := conn.WriteJSON(frame{
+		ID: 1, Src: rpcSource, Method: "Shelly.GetStatus",
+	}); err != nil {
+		return fmt.Errorf("sending request: %w", err)
+	}
+	m.setConnected(c, true)
+	defer m.setConnected(c, false)
+	// we might have missed changes while disconnected.
+	if !m.emit(ctx, obj) {
+		return nil
+	}
+
+	for {
+		var f frame
+		if err := conn.ReadJSON(&f); err != nil {
+			return fmt.Errorf("reading: %w", err)
+		}
+		if !strings.HasPrefix(f.Method, "Notify") {
+			continue
+		}
+		if !m.emit(ctx, obj) {
+			return nil
+		}
+	}
+}
+
+func (m *Manager) emit(ctx context.Context, obj client.Object) bool {
+	select {
+	case m.events <- event.GenericEvent{Object: obj}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Converts the HTTP endpoint of the device into its RPC WebSocket URL.
+func websocketURL(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("parsing endpoint URL: %w", err)
+	}
+	switch u.Scheme {
+	case "https":
+		u.Scheme = "wss"
+	default:
+		u.Scheme = "ws"
+	}
+	u.Path = "/rpc"
+	return u.String(), nil
+}
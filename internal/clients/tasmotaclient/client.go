@@ -0,0 +1,277 @@
+package tasmotaclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/thetechnick/iot-operator/internal/clients"
+)
+
+// Client for the Tasmota firmware command API.
+// Tasmota numbers shutters and relays starting at 1.
+type Client struct {
+	*clients.Client
+}
+
+func NewClient(opts clients.ClientOption) *Client {
+	return &Client{
+		Client: clients.NewClient(opts),
+	}
+}
+
+// Executes a Tasmota command, e.g. "ShutterPosition1 40".
+func (c *Client) Command(
+	ctx context.Context,
+	cmnd string,
+	result interface{},
+) error {
+	return c.Do(
+		ctx, http.MethodGet, "cm", url.Values{
+			"cmnd": []string{cmnd},
+		}, nil, result,
+	)
+}
+
+func (c *Client) ShutterStatus(
+	ctx context.Context,
+	shutter int,
+) (ShutterStatus, error) {
+	return c.shutterCommand(ctx, shutter, "ShutterPosition"+strconv.Itoa(shutter))
+}
+
+func (c *Client) ShutterPosition(
+	ctx context.Context,
+	shutter int,
+	position int,
+) (ShutterStatus, error) {
+	return c.shutterCommand(ctx, shutter,
+		"ShutterPosition"+strconv.Itoa(shutter)+" "+strconv.Itoa(position))
+}
+
+func (c *Client) ShutterStop(
+	ctx context.Context,
+	shutter int,
+) (ShutterStatus, error) {
+	return c.shutterCommand(ctx, shutter, "ShutterStop"+strconv.Itoa(shutter))
+}
+
+func (c *Client) shutterCommand(
+	ctx context.Context,
+	shutter int,
+	cmnd string,
+) (ShutterStatus, error) {
+	// responses may contain other keys, e.g. POWER states,
+	// so only the requested shutter is decoded.
+	res := map[string]json.RawMessage{}
+	if err := c.Command(ctx, cmnd, &res); err != nil {
+		return ShutterStatus{}, err
+	}
+
+	key := "Shutter" + strconv.Itoa(shutter)
+	raw, ok := res[key]
+	if !ok {
+		return ShutterStatus{}, fmt.Errorf("%q not in response to command %q", key, cmnd)
+	}
+	var status ShutterStatus
+	if err := json.Unmarshal(raw, &status); err != nil {
+		return ShutterStatus{}, fmt.Errorf("decoding %q: %w", key, err)
+	}
+	return status, nil
+}
+
+// Returns true if the relay is on.
+func (c *Client) Power(
+	ctx context.Context,
+	relay int,
+) (bool, error) {
+	return c.powerCommand(ctx, relay, "Power"+strconv.Itoa(relay))
+}
+
+func (c *Client) SetPower(
+	ctx context.Context,
+	relay int,
+	on bool,
+) (bool, error) {
+	state := "OFF"
+	if on {
+		state = "ON"
+	}
+	return c.powerCommand(ctx, relay, "Power"+strconv.Itoa(relay)+" "+state)
+}
+
+func (c *Client) powerCommand(
+	ctx context.Context,
+	relay int,
+	cmnd string,
+) (bool, error) {
+	res := map[string]string{}
+	if err := c.Command(ctx, cmnd, &res); err != nil {
+		return false, err
+	}
+
+	// devices with a single relay report "POWER" instead of "POWER1".
+	key := "POWER" + strconv.Itoa(relay)
+	state, ok := res[key]
+	if !ok && relay == 1 {
+		state, ok = res["POWER"]
+	}
+	if !ok {
+		return false, fmt.Errorf("%q not in response to command %q", key, cmnd)
+	}
+	return strings.EqualFold(state, "ON"), nil
+}
+
+// Returns the number of relays via "Status 11",
+// which reports a POWER or POWER<n> key per relay.
+func (c *Client) RelayCount(
+	ctx context.Context,
+) (int, error) {
+	res := struct {
+		StatusSTS map[string]interface{} `json:"StatusSTS"`
+	}{}
+	if err := c.Command(ctx, "Status 11", &res); err != nil {
+		return 0, err
+	}
+
+	var relays int
+	for key := range res.StatusSTS {
+		if powerKey.MatchString(key) {
+			relays++
+		}
+	}
+	return relays, nil
+}
+
+var powerKey = regexp.MustCompile(`^POWER[0-9]*$`)
+
+// Returns the power meter readings via "Status 8".
+func (c *Client) SensorStatus(
+	ctx context.Context,
+) (res SensorStatus, err error) {
+	return res, c.Command(ctx, "Status 8", &res)
+}
+
+// Returns all status information via "Status 0".
+func (c *Client) Status(
+	ctx context.Context,
+) (res Status, err error) {
+	return res, c.Command(ctx, "Status 0", &res)
+}
+
+type Status struct {
+	Status struct {
+		// Configured friendly name.
+		DeviceName string `json:"DeviceName"`
+		// MQTT topic of the device.
+		Topic string `json:"Topic"`
+	} `json:"Status"`
+	StatusFWR struct {
+		// Firmware version, e.g. 12.0.2(tasmota).
+		Version string `json:"Version"`
+		// Hardware, e.g. ESP8266EX.
+		Hardware string `json:"Hardware"`
+	} `json:"StatusFWR"`
+	StatusSTS struct {
+		// Uptime in seconds.
+		UptimeSec int64 `json:"UptimeSec"`
+		Wifi      struct {
+			// Signal strength in dBm.
+			Signal int `json:"Signal"`
+		} `json:"Wifi"`
+	} `json:"StatusSTS"`
+	StatusNET struct {
+		Hostname string `json:"Hostname"`
+		// MAC address, e.g. A4:CF:12:C4:5B:BE.
+		Mac string `json:"Mac"`
+	} `json:"StatusNET"`
+}
+
+// Returns the shutter configuration via "Status 13".
+func (c *Client) ShutterSettings(
+	ctx context.Context,
+) (res ShutterSettings, err error) {
+	return res, c.Command(ctx, "Status 13", &res)
+}
+
+type ShutterSettings struct {
+	// Settings per shutter, keyed SHT0, SHT1, ...
+	// Empty when no shutters are configured.
+	StatusSHT map[string]json.RawMessage `json:"StatusSHT,omitempty"`
+}
+
+// Returns the configuration of the given shutter via "Status 13".
+func (c *Client) ShutterConfig(
+	ctx context.Context,
+	shutter int,
+) (ShutterConfig, error) {
+	settings, err := c.ShutterSettings(ctx)
+	if err != nil {
+		return ShutterConfig{}, err
+	}
+
+	// Status 13 numbers shutters starting at 0.
+	key := "SHT" + strconv.Itoa(shutter-1)
+	raw, ok := settings.StatusSHT[key]
+	if !ok {
+		return ShutterConfig{}, fmt.Errorf("shutter %d is not configured", shutter)
+	}
+	var config ShutterConfig
+	if err := json.Unmarshal(raw, &config); err != nil {
+		return ShutterConfig{}, fmt.Errorf("decoding %q: %w", key, err)
+	}
+	return config, nil
+}
+
+type ShutterConfig struct {
+	// Relays driving the motor up and down.
+	Relay1 int `json:"Relay1"`
+	Relay2 int `json:"Relay2"`
+}
+
+type ShutterStatus struct {
+	// Position in percentage open.
+	Position int `json:"Position"`
+	// 1 while opening, -1 while closing and 0 when stopped.
+	Direction int `json:"Direction"`
+	// Target position in percentage open.
+	Target int `json:"Target"`
+}
+
+const (
+	DirectionOpening = 1
+	DirectionClosing = -1
+)
+
+type SensorStatus struct {
+	StatusSNS struct {
+		// Only present on devices with a power meter.
+		Energy *Energy `json:"ENERGY"`
+	} `json:"StatusSNS"`
+}
+
+type Energy struct {
+	// Current power consumption in Watts.
+	// Devices with multiple meters report a list instead.
+	Power interface{} `json:"Power"`
+}
+
+// Returns the power consumption of the given relay in Watts.
+func (e *Energy) RelayPower(relay int) (float64, bool) {
+	switch p := e.Power.(type) {
+	case float64:
+		return p, relay == 1
+	case []interface{}:
+		if relay < 1 || relay > len(p) {
+			return 0, false
+		}
+		power, ok := p[relay-1].(float64)
+		return power, ok
+	}
+	return 0, false
+}
@@ -0,0 +1,243 @@
+package discovereddevices
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+	"github.com/thetechnick/iot-operator/internal/discovery"
+	"github.com/thetechnick/iot-operator/internal/identity"
+)
+
+const identityTimeout = 5 * time.Second
+
+type DiscoveredDeviceReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+	// Interval to re-query devices added by address.
+	DefaultRequeueInterval time.Duration
+}
+
+func (r *DiscoveredDeviceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(
+			&iotv1alpha1.DiscoveredDevice{},
+			builder.WithPredicates(predicate.Funcs{
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					// LastSeenTime is updated on every scan,
+					// only spec and address changes are relevant.
+					oldDevice := e.ObjectOld.(*iotv1alpha1.DiscoveredDevice)
+					newDevice := e.ObjectNew.(*iotv1alpha1.DiscoveredDevice)
+					return oldDevice.Generation != newDevice.Generation ||
+						oldDevice.Status.Address != newDevice.Status.Address
+				},
+			}),
+		).
+		Complete(r)
+}
+
+func (r *DiscoveredDeviceReconciler) Reconcile(
+	ctx context.Context, req ctrl.Request) (res ctrl.Result, err error) {
+	log := r.Log.WithValues("discovereddevice", req.NamespacedName.String())
+	defer log.Info("reconciled")
+
+	device := &iotv1alpha1.DiscoveredDevice{}
+	if err := r.Get(ctx, req.NamespacedName, device); err != nil {
+		return res, client.IgnoreNotFound(err)
+	}
+
+	// Identity handling for devices added by address
+	if len(device.Spec.Address) > 0 {
+		r.identify(ctx, device)
+		res.RequeueAfter = r.DefaultRequeueInterval
+	}
+
+	// Adoption handling
+	if err := r.adopt(ctx, device); err != nil {
+		return res, err
+	}
+
+	device.Status.ObservedGeneration = device.Generation
+	if err := r.Status().Update(ctx, device); err != nil {
+		return res, fmt.Errorf("updating DiscoveredDevice status: %w", err)
+	}
+	return
+}
+
+// Queries the identity of the device at spec.address.
+func (r *DiscoveredDeviceReconciler) identify(
+	ctx context.Context, device *iotv1alpha1.DiscoveredDevice,
+) {
+	identityCtx, cancel := context.WithTimeout(ctx, identityTimeout)
+	defer cancel()
+	id, err := identity.Query(identityCtx, device.Spec.Address)
+	if err != nil {
+		meta.SetStatusCondition(&device.Status.Conditions, metav1.Condition{
+			Type:    iotv1alpha1.DiscoveredDeviceIdentified,
+			Status:  metav1.ConditionFalse,
+			Reason:  "QueryFailed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if len(device.Status.MAC) > 0 && device.Status.MAC != id.MAC {
+		// keep the adopted device, someone else took over the address.
+		meta.SetStatusCondition(&device.Status.Conditions, metav1.Condition{
+			Type:   iotv1alpha1.DiscoveredDeviceIdentified,
+			Status: metav1.ConditionFalse,
+			Reason: "IdentityMismatch",
+			Message: fmt.Sprintf("expected device with MAC %s, found %s",
+				device.Status.MAC, id.MAC),
+		})
+		return
+	}
+
+	meta.SetStatusCondition(&device.Status.Conditions, metav1.Condition{
+		Type:    iotv1alpha1.DiscoveredDeviceIdentified,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Identified",
+		Message: fmt.Sprintf("identified %s %s", id.Model, id.DeviceID),
+	})
+	discovery.ApplyIdentity(&device.Status, device.Spec.Address, id)
+}
+
+// Creates the suggested object when approved and
+// keeps its endpoint in sync with the device address.
+func (r *DiscoveredDeviceReconciler) adopt(
+	ctx context.Context, device *iotv1alpha1.DiscoveredDevice,
+) error {
+	obj, sync := suggestedObject(device)
+	switch {
+	case obj == nil:
+		meta.SetStatusCondition(&device.Status.Conditions, metav1.Condition{
+			Type:    iotv1alpha1.DiscoveredDeviceAdopted,
+			Status:  metav1.ConditionFalse,
+			Reason:  "Unsupported",
+			Message: "device is not supported",
+		})
+		return nil
+	case !device.Spec.Approved:
+		meta.SetStatusCondition(&device.Status.Conditions, metav1.Condition{
+			Type:    iotv1alpha1.DiscoveredDeviceAdopted,
+			Status:  metav1.ConditionFalse,
+			Reason:  "WaitingForApproval",
+			Message: "set spec.approved to adopt the device",
+		})
+		return nil
+	}
+
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	existing := obj.DeepCopyObject().(client.Object)
+	err := r.Get(ctx, client.ObjectKeyFromObject(obj), existing)
+	switch {
+	case errors.IsNotFound(err):
+		if err := r.Create(ctx, obj); err != nil {
+			return fmt.Errorf("creating %s: %w", kind, err)
+		}
+
+	case err != nil:
+		return fmt.Errorf("getting %s: %w", kind, err)
+
+	case existing.GetLabels()[iotv1alpha1.DiscoveredDeviceLabel] != device.Name:
+		meta.SetStatusCondition(&device.Status.Conditions, metav1.Condition{
+			Type:   iotv1alpha1.DiscoveredDeviceAdopted,
+			Status: metav1.ConditionFalse,
+			Reason: "NameConflict",
+			Message: fmt.Sprintf("%s %s already exists and was not created from this device",
+				kind, obj.GetName()),
+		})
+		return nil
+
+	case sync(existing):
+		// device address changed
+		if err := r.Update(ctx, existing); err != nil {
+			return fmt.Errorf("updating %s endpoint: %w", kind, err)
+		}
+	}
+
+	meta.SetStatusCondition(&device.Status.Conditions, metav1.Condition{
+		Type:    iotv1alpha1.DiscoveredDeviceAdopted,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Adopted",
+		Message: fmt.Sprintf("adopted as %s %s", kind, obj.GetName()),
+	})
+	return nil
+}
+
+// Returns the object suggested for the device and a function
+// updating the endpoint URL of an existing object,
+// returning true if it was changed.
+func suggestedObject(
+	device *iotv1alpha1.DiscoveredDevice,
+) (client.Object, func(existing client.Object) bool) {
+	address := device.Status.Address
+	objectMeta := metav1.ObjectMeta{
+		Name:      device.Name,
+		Namespace: device.Namespace,
+		Labels: map[string]string{
+			iotv1alpha1.DiscoveredDeviceLabel: device.Name,
+		},
+	}
+
+	switch {
+	case device.Status.SuggestedRollerShutter != nil:
+		obj := &iotv1alpha1.RollerShutter{
+			ObjectMeta: objectMeta,
+			Spec:       *device.Status.SuggestedRollerShutter.DeepCopy(),
+		}
+		obj.SetGroupVersionKind(iotv1alpha1.GroupVersion.WithKind("RollerShutter"))
+		return obj, func(existing client.Object) bool {
+			rs := existing.(*iotv1alpha1.RollerShutter)
+			if rs.Spec.Endpoint.MQTT != nil || rs.Spec.Endpoint.URL == address {
+				return false
+			}
+			rs.Spec.Endpoint.URL = address
+			return true
+		}
+
+	case device.Status.SuggestedSwitch != nil:
+		obj := &iotv1alpha1.Switch{
+			ObjectMeta: objectMeta,
+			Spec:       *device.Status.SuggestedSwitch.DeepCopy(),
+		}
+		obj.SetGroupVersionKind(iotv1alpha1.GroupVersion.WithKind("Switch"))
+		return obj, func(existing client.Object) bool {
+			sw := existing.(*iotv1alpha1.Switch)
+			if sw.Spec.Endpoint.URL == address {
+				return false
+			}
+			sw.Spec.Endpoint.URL = address
+			return true
+		}
+
+	case device.Status.SuggestedLight != nil:
+		obj := &iotv1alpha1.Light{
+			ObjectMeta: objectMeta,
+			Spec:       *device.Status.SuggestedLight.DeepCopy(),
+		}
+		obj.SetGroupVersionKind(iotv1alpha1.GroupVersion.WithKind("Light"))
+		return obj, func(existing client.Object) bool {
+			light := existing.(*iotv1alpha1.Light)
+			if light.Spec.Endpoint.URL == address {
+				return false
+			}
+			light.Spec.Endpoint.URL = address
+			return true
+		}
+	}
+	return nil, nil
+}
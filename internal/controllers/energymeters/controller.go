@@ -0,0 +1,141 @@
+package energymeters
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+)
+
+type EnergyMeterReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+func (r *EnergyMeterReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(
+			&iotv1alpha1.EnergyMeter{},
+			// power and counter totals are written to the status on every poll,
+			// the meter is only read again after the PollInterval.
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Complete(r)
+}
+
+func (r *EnergyMeterReconciler) Reconcile(
+	ctx context.Context, req ctrl.Request) (res ctrl.Result, err error) {
+	log := r.Log.WithValues("energymeter", req.NamespacedName.String())
+	defer log.Info("reconciled")
+
+	energyMeter := &iotv1alpha1.EnergyMeter{}
+	if err := r.Get(ctx, req.NamespacedName, energyMeter); err != nil {
+		return res, client.IgnoreNotFound(err)
+	}
+
+	// Determine Client
+	dt := energyMeter.Spec.DeviceType
+	dev := newDevice(energyMeter)
+	if dev == nil {
+		meta.SetStatusCondition(&energyMeter.Status.Conditions, metav1.Condition{
+			Type:   iotv1alpha1.EnergyMeterReachable,
+			Status: metav1.ConditionFalse,
+			Reason: "UnkownDeviceType",
+			Message: fmt.Sprintf("Unkown device type %q, must be one of: [%s]",
+				dt, strings.Join(knownDeviceTypes, ", ")),
+		})
+		return r.updateStatus(ctx, energyMeter)
+	}
+
+	// Status handling
+	reading, err := dev.Read(ctx)
+	if err != nil {
+		meta.SetStatusCondition(&energyMeter.Status.Conditions, metav1.Condition{
+			Type:    iotv1alpha1.EnergyMeterReachable,
+			Status:  metav1.ConditionFalse,
+			Reason:  "ReadError",
+			Message: err.Error(),
+		})
+		return r.updateStatus(ctx, energyMeter)
+	}
+	meta.SetStatusCondition(&energyMeter.Status.Conditions, metav1.Condition{
+		Type:    iotv1alpha1.EnergyMeterReachable,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Connected",
+		Message: "connected to device",
+	})
+
+	now := metav1.Now()
+	energyMeter.Status.LastReadingTime = &now
+	energyMeter.Status.Power = quantity(reading.Power)
+	energyMeter.Status.Voltage = nil
+	if reading.Voltage != nil {
+		energyMeter.Status.Voltage = quantity(*reading.Voltage)
+	}
+	energyMeter.Status.Current = nil
+	if reading.Current != nil {
+		energyMeter.Status.Current = quantity(*reading.Current)
+	}
+	accumulateEnergy(&energyMeter.Status, quantity(reading.TotalEnergy), now)
+	return r.updateStatus(ctx, energyMeter)
+}
+
+func (r *EnergyMeterReconciler) updateStatus(
+	ctx context.Context, energyMeter *iotv1alpha1.EnergyMeter,
+) (res ctrl.Result, err error) {
+	energyMeter.Status.ObservedGeneration = energyMeter.Generation
+	if err := r.Status().Update(ctx, energyMeter); err != nil {
+		return res, fmt.Errorf("updating EnergyMeter status: %w", err)
+	}
+
+	res.RequeueAfter = energyMeter.Spec.PollInterval.Duration
+	return
+}
+
+// Adds the energy consumed since the last reading to the total.
+// Device counters reset when the device reboots,
+// in this case everything the device counted since the reset is added.
+func accumulateEnergy(
+	status *iotv1alpha1.EnergyMeterStatus,
+	deviceTotal *resource.Quantity, now metav1.Time,
+) {
+	lastDeviceTotal := status.DeviceTotalEnergy
+	status.DeviceTotalEnergy = deviceTotal
+
+	if status.TotalEnergy == nil || lastDeviceTotal == nil {
+		// first reading
+		total := deviceTotal.DeepCopy()
+		status.TotalEnergy = &total
+		return
+	}
+
+	delta := deviceTotal.DeepCopy()
+	if deviceTotal.Cmp(*lastDeviceTotal) < 0 {
+		// counter reset
+		status.LastCounterResetTime = &now
+	} else {
+		delta.Sub(*lastDeviceTotal)
+	}
+
+	total := status.TotalEnergy.DeepCopy()
+	total.Add(delta)
+	status.TotalEnergy = &total
+}
+
+// Converts a float into a Quantity with milli precision.
+func quantity(f float64) *resource.Quantity {
+	return resource.NewMilliQuantity(int64(math.Round(f*1000)), resource.DecimalSI)
+}
@@ -0,0 +1,111 @@
+package energymeters
+
+import (
+	"context"
+	"fmt"
+
+	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+	"github.com/thetechnick/iot-operator/internal/clients"
+	"github.com/thetechnick/iot-operator/internal/clients/shellymeterclient"
+)
+
+const (
+	shellyEM      = "ShellyEM"
+	shelly3EM     = "Shelly3EM"
+	shelly25Meter = "Shelly25Meter"
+)
+
+// Device independent interface to read energy meters.
+type device interface {
+	Read(ctx context.Context) (reading, error)
+}
+
+// Device independent energy meter reading.
+type reading struct {
+	// Active power in Watts.
+	Power float64
+	// Voltage in Volts, nil if not supported.
+	Voltage *float64
+	// Current in Amperes, nil if not supported.
+	Current *float64
+	// Energy counter of the device in Watt-hours.
+	TotalEnergy float64
+}
+
+// Returns the device implementation for the given EnergyMeter
+// or nil, if the device type is unknown.
+func newDevice(energyMeter *iotv1alpha1.EnergyMeter) device {
+	endpoint := clients.WithEndpoint(energyMeter.Spec.Endpoint.URL)
+	switch energyMeter.Spec.DeviceType {
+	case shellyEM, shelly3EM:
+		return &shellyEMDevice{
+			c:       shellymeterclient.NewClient(endpoint),
+			channel: energyMeter.Spec.Channel,
+		}
+	case shelly25Meter:
+		return &shelly25MeterDevice{
+			c:       shellymeterclient.NewClient(endpoint),
+			channel: energyMeter.Spec.Channel,
+		}
+	}
+	return nil
+}
+
+// List of all supported device types for error reporting.
+var knownDeviceTypes = []string{
+	shellyEM,
+	shelly3EM,
+	shelly25Meter,
+}
+
+// Shelly EM and 3EM.
+type shellyEMDevice struct {
+	c       *shellymeterclient.Client
+	channel int
+}
+
+func (d *shellyEMDevice) Read(ctx context.Context) (reading, error) {
+	status, err := d.c.EMeter(ctx, d.channel)
+	if err != nil {
+		return reading{}, err
+	}
+	if !status.IsValid {
+		return reading{}, fmt.Errorf("meter %d reports invalid values", d.channel)
+	}
+
+	voltage := status.Voltage
+	return reading{
+		Power:       status.Power,
+		Voltage:     &voltage,
+		Current:     status.Current,
+		TotalEnergy: status.Total,
+	}, nil
+}
+
+// Per-channel meters of the Shelly 2.5.
+type shelly25MeterDevice struct {
+	c       *shellymeterclient.Client
+	channel int
+}
+
+func (d *shelly25MeterDevice) Read(ctx context.Context) (reading, error) {
+	meter, err := d.c.Meter(ctx, d.channel)
+	if err != nil {
+		return reading{}, err
+	}
+	if !meter.IsValid {
+		return reading{}, fmt.Errorf("meter %d reports invalid values", d.channel)
+	}
+
+	status, err := d.c.Status(ctx)
+	if err != nil {
+		return reading{}, err
+	}
+
+	return reading{
+		Power:   meter.Power,
+		Voltage: status.Voltage,
+		// Watt-minutes to Watt-hours
+		TotalEnergy: meter.Total / 60,
+	}, nil
+}
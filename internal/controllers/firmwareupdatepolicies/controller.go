@@ -0,0 +1,344 @@
+package firmwareupdatepolicies
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+	// the operator image ships without zoneinfo
+	_ "time/tzdata"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+)
+
+type FirmwareUpdatePolicyReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+func (r *FirmwareUpdatePolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(
+			&iotv1alpha1.FirmwareUpdatePolicy{},
+			// per-device progress is written to the status while rolling out,
+			// the next device is only checked after the PollInterval.
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Complete(r)
+}
+
+func (r *FirmwareUpdatePolicyReconciler) Reconcile(
+	ctx context.Context, req ctrl.Request) (res ctrl.Result, err error) {
+	log := r.Log.WithValues("firmwareupdatepolicy", req.NamespacedName.String())
+	defer log.Info("reconciled")
+
+	policy := &iotv1alpha1.FirmwareUpdatePolicy{}
+	if err := r.Get(ctx, req.NamespacedName, policy); err != nil {
+		return res, client.IgnoreNotFound(err)
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.RollerShutterSelector)
+	if err != nil {
+		return res, fmt.Errorf("parsing RollerShutter selector: %w", err)
+	}
+	rollerShutterList := &iotv1alpha1.RollerShutterList{}
+	if err := r.List(ctx, rollerShutterList,
+		client.InNamespace(policy.Namespace),
+		client.MatchingLabelsSelector{Selector: selector},
+	); err != nil {
+		return res, fmt.Errorf("listing RollerShutters: %w", err)
+	}
+	rollerShutters := rollerShutterList.Items
+	sort.Slice(rollerShutters, func(i, j int) bool {
+		return rollerShutters[i].Name < rollerShutters[j].Name
+	})
+
+	inWindow := r.checkMaintenanceWindow(policy, time.Now())
+	active, err := r.activeRequests(ctx, policy.Namespace)
+	if err != nil {
+		return res, err
+	}
+
+	previous := map[string]iotv1alpha1.FirmwareUpdateDeviceStatus{}
+	for _, d := range policy.Status.Devices {
+		previous[d.Name] = d
+	}
+
+	// Track progress of running updates first,
+	// so we know how many new updates may be started.
+	devices := make([]iotv1alpha1.FirmwareUpdateDeviceStatus, len(rollerShutters))
+	var updating int
+	for i := range rollerShutters {
+		devices[i] = r.observe(policy, &rollerShutters[i], previous[rollerShutters[i].Name])
+		if devices[i].Phase == iotv1alpha1.FirmwareUpdatePhaseUpdating {
+			updating++
+		}
+	}
+
+	// Start new updates
+	for i := range rollerShutters {
+		d := &devices[i]
+		if d.Phase != iotv1alpha1.FirmwareUpdatePhasePending {
+			continue
+		}
+		if !inWindow {
+			d.Message = "waiting for maintenance window"
+			continue
+		}
+		if updating >= policy.Spec.MaxConcurrent {
+			d.Message = "waiting for other updates to complete"
+			continue
+		}
+		if active[rollerShutters[i].Name] {
+			// the RollerShutter controller holds new requests while updating,
+			// but requests in progress have to finish first.
+			d.Message = "waiting for requests to complete"
+			continue
+		}
+		if started := r.startUpdate(ctx, log, &rollerShutters[i], d); started {
+			updating++
+		}
+	}
+
+	// Handle status
+	policy.Status.Devices = devices
+	policy.Status.Pending, policy.Status.Updating, policy.Status.Failed = 0, 0, 0
+	for _, d := range devices {
+		switch d.Phase {
+		case iotv1alpha1.FirmwareUpdatePhasePending:
+			policy.Status.Pending++
+		case iotv1alpha1.FirmwareUpdatePhaseUpdating:
+			policy.Status.Updating++
+		case iotv1alpha1.FirmwareUpdatePhaseFailed:
+			policy.Status.Failed++
+		}
+	}
+	policy.Status.ObservedGeneration = policy.Generation
+	if err := r.Status().Update(ctx, policy); err != nil {
+		return res, fmt.Errorf("updating FirmwareUpdatePolicy status: %w", err)
+	}
+
+	res.RequeueAfter = policy.Spec.PollInterval.Duration
+	return
+}
+
+// Returns the names of RollerShutters with Pending or Moving requests,
+// all requests that are not completed yet.
+func (r *FirmwareUpdatePolicyReconciler) activeRequests(
+	ctx context.Context, namespace string,
+) (map[string]bool, error) {
+	requestList := &iotv1alpha1.RollerShutterRequestList{}
+	if err := r.List(ctx, requestList, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("listing RollerShutterRequests in namespace %s: %w", namespace, err)
+	}
+
+	active := map[string]bool{}
+	for _, req := range requestList.Items {
+		if meta.IsStatusConditionTrue(
+			req.Status.Conditions, iotv1alpha1.RollerShutterRequestCompleted) {
+			continue
+		}
+		// new requests have no phase yet and count as Pending.
+		active[req.Spec.RollerShutter.Name] = true
+	}
+	return active, nil
+}
+
+// Determines the update state of a device
+// from the firmware reported in the RollerShutter status.
+func (r *FirmwareUpdatePolicyReconciler) observe(
+	policy *iotv1alpha1.FirmwareUpdatePolicy,
+	rollerShutter *iotv1alpha1.RollerShutter,
+	previous iotv1alpha1.FirmwareUpdateDeviceStatus,
+) iotv1alpha1.FirmwareUpdateDeviceStatus {
+	d := previous
+	d.Name = rollerShutter.Name
+
+	info := rollerShutter.Status.DeviceInfo
+	if info == nil {
+		if d.Phase == iotv1alpha1.FirmwareUpdatePhaseUpdating {
+			// device is rebooting
+			return r.checkTimeout(policy, d)
+		}
+		d.Phase = iotv1alpha1.FirmwareUpdatePhasePending
+		d.Message = "waiting for device to report its firmware"
+		return d
+	}
+
+	switch d.Phase {
+	case iotv1alpha1.FirmwareUpdatePhaseUpdating:
+		if info.Firmware != d.FromVersion {
+			d.Phase = iotv1alpha1.FirmwareUpdatePhaseUpdated
+			d.Message = fmt.Sprintf("updated to %s", info.Firmware)
+			return d
+		}
+		return r.checkTimeout(policy, d)
+
+	case iotv1alpha1.FirmwareUpdatePhaseFailed:
+		if len(info.AvailableFirmware) > 0 && info.AvailableFirmware == d.ToVersion {
+			// don't retry the same version
+			return d
+		}
+
+	case iotv1alpha1.FirmwareUpdatePhaseUpdated:
+		if len(info.AvailableFirmware) == 0 {
+			return d
+		}
+	}
+
+	if len(info.AvailableFirmware) == 0 {
+		return iotv1alpha1.FirmwareUpdateDeviceStatus{
+			Name:        d.Name,
+			Phase:       iotv1alpha1.FirmwareUpdatePhaseUpToDate,
+			FromVersion: info.Firmware,
+			Message:     "firmware is up-to-date",
+		}
+	}
+	return iotv1alpha1.FirmwareUpdateDeviceStatus{
+		Name:        d.Name,
+		Phase:       iotv1alpha1.FirmwareUpdatePhasePending,
+		FromVersion: info.Firmware,
+		ToVersion:   info.AvailableFirmware,
+	}
+}
+
+// Marks running updates as failed, when they exceed the update timeout.
+func (r *FirmwareUpdatePolicyReconciler) checkTimeout(
+	policy *iotv1alpha1.FirmwareUpdatePolicy,
+	d iotv1alpha1.FirmwareUpdateDeviceStatus,
+) iotv1alpha1.FirmwareUpdateDeviceStatus {
+	if d.StartTime != nil &&
+		time.Since(d.StartTime.Time) > policy.Spec.UpdateTimeout.Duration {
+		d.Phase = iotv1alpha1.FirmwareUpdatePhaseFailed
+		d.Message = fmt.Sprintf(
+			"device did not report firmware %s within %s",
+			d.ToVersion, policy.Spec.UpdateTimeout.Duration)
+	}
+	return d
+}
+
+// Triggers the update on the device, if it's safe to do so.
+// Returns true if the update was started.
+func (r *FirmwareUpdatePolicyReconciler) startUpdate(
+	ctx context.Context, log logr.Logger,
+	rollerShutter *iotv1alpha1.RollerShutter,
+	d *iotv1alpha1.FirmwareUpdateDeviceStatus,
+) bool {
+	if rollerShutter.Status.Phase != iotv1alpha1.RollerShutterPhaseIdle {
+		d.Message = "waiting for device to stop moving"
+		return false
+	}
+	if !meta.IsStatusConditionTrue(
+		rollerShutter.Status.Conditions, iotv1alpha1.RollerShutterReachable) {
+		d.Message = "waiting for device to become reachable"
+		return false
+	}
+	if meta.IsStatusConditionTrue(
+		rollerShutter.Status.Conditions, iotv1alpha1.RollerShutterIdentityMismatch) {
+		d.Message = "device identity mismatch"
+		return false
+	}
+
+	u := newUpdater(rollerShutter)
+	if u == nil {
+		d.Phase = iotv1alpha1.FirmwareUpdatePhaseFailed
+		d.Message = "device does not support remote updates"
+		return false
+	}
+	if err := u.Update(ctx); err != nil {
+		d.Phase = iotv1alpha1.FirmwareUpdatePhaseFailed
+		d.Message = fmt.Sprintf("triggering update: %v", err)
+		return false
+	}
+
+	log.Info("started firmware update",
+		"rollershutter", rollerShutter.Name, "from", d.FromVersion, "to", d.ToVersion)
+	now := metav1.Now()
+	d.Phase = iotv1alpha1.FirmwareUpdatePhaseUpdating
+	d.StartTime = &now
+	d.Message = fmt.Sprintf("updating to %s", d.ToVersion)
+	return true
+}
+
+// Reports whether updates may be started at the given time.
+func (r *FirmwareUpdatePolicyReconciler) checkMaintenanceWindow(
+	policy *iotv1alpha1.FirmwareUpdatePolicy, now time.Time,
+) bool {
+	window := policy.Spec.MaintenanceWindow
+	if window == nil {
+		meta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+			Type:    iotv1alpha1.FirmwareUpdatePolicyInMaintenanceWindow,
+			Status:  metav1.ConditionTrue,
+			Reason:  "NoMaintenanceWindow",
+			Message: "no maintenance window configured",
+		})
+		return true
+	}
+
+	in, err := inMaintenanceWindow(*window, now)
+	if err != nil {
+		meta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+			Type:    iotv1alpha1.FirmwareUpdatePolicyInMaintenanceWindow,
+			Status:  metav1.ConditionFalse,
+			Reason:  "InvalidMaintenanceWindow",
+			Message: err.Error(),
+		})
+		return false
+	}
+
+	if in {
+		meta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+			Type:   iotv1alpha1.FirmwareUpdatePolicyInMaintenanceWindow,
+			Status: metav1.ConditionTrue,
+			Reason: "InMaintenanceWindow",
+			Message: fmt.Sprintf("within maintenance window %s-%s %s",
+				window.Start, window.End, window.TimeZone),
+		})
+	} else {
+		meta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+			Type:   iotv1alpha1.FirmwareUpdatePolicyInMaintenanceWindow,
+			Status: metav1.ConditionFalse,
+			Reason: "OutsideMaintenanceWindow",
+			Message: fmt.Sprintf("outside of maintenance window %s-%s %s",
+				window.Start, window.End, window.TimeZone),
+		})
+	}
+	return in
+}
+
+func inMaintenanceWindow(
+	window iotv1alpha1.MaintenanceWindow, now time.Time,
+) (bool, error) {
+	loc, err := time.LoadLocation(window.TimeZone)
+	if err != nil {
+		return false, fmt.Errorf("loading time zone: %w", err)
+	}
+	start, err := time.Parse("15:04", window.Start)
+	if err != nil {
+		return false, fmt.Errorf("parsing start: %w", err)
+	}
+	end, err := time.Parse("15:04", window.End)
+	if err != nil {
+		return false, fmt.Errorf("parsing end: %w", err)
+	}
+
+	now = now.In(loc)
+	minute := now.Hour()*60 + now.Minute()
+	startMinute := start.Hour()*60 + start.Minute()
+	endMinute := end.Hour()*60 + end.Minute()
+	if startMinute <= endMinute {
+		return minute >= startMinute && minute < endMinute, nil
+	}
+	// window spans midnight
+	return minute >= startMinute || minute < endMinute, nil
+}
@@ -0,0 +1,57 @@
+package firmwareupdatepolicies
+
+import (
+	"context"
+
+	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+	"github.com/thetechnick/iot-operator/internal/clients"
+	"github.com/thetechnick/iot-operator/internal/clients/shelly25rollerclient"
+	"github.com/thetechnick/iot-operator/internal/clients/shellyrpcclient"
+)
+
+const (
+	shelly25Roller  = "Shelly25Roller"
+	shellyPlusCover = "ShellyPlusCover"
+)
+
+// Device independent interface to trigger firmware updates.
+type updater interface {
+	Update(ctx context.Context) error
+}
+
+// Returns the updater for the given RollerShutter
+// or nil, if the device can't be updated remotely.
+func newUpdater(rollerShutter *iotv1alpha1.RollerShutter) updater {
+	if rollerShutter.Spec.Endpoint.MQTT != nil {
+		return nil
+	}
+
+	endpoint := clients.WithEndpoint(rollerShutter.Spec.Endpoint.URL)
+	switch rollerShutter.Spec.DeviceType {
+	case shelly25Roller:
+		return &shelly25RollerUpdater{
+			c: shelly25rollerclient.NewClient(endpoint),
+		}
+	case shellyPlusCover:
+		return &shellyPlusCoverUpdater{
+			c: shellyrpcclient.NewClient(endpoint),
+		}
+	}
+	return nil
+}
+
+type shelly25RollerUpdater struct {
+	c *shelly25rollerclient.Client
+}
+
+func (u *shelly25RollerUpdater) Update(ctx context.Context) error {
+	return u.c.OTAUpdate(ctx)
+}
+
+type shellyPlusCoverUpdater struct {
+	c *shellyrpcclient.Client
+}
+
+func (u *shellyPlusCoverUpdater) Update(ctx context.Context) error {
+	return u.c.ShellyUpdate(ctx)
+}
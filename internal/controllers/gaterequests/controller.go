@@ -0,0 +1,67 @@
+package gaterequests
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+)
+
+const requestHistoryLimit = 5
+
+type GateRequestReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+func (r *GateRequestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&iotv1alpha1.GateRequest{}).
+		Complete(r)
+}
+
+func (r *GateRequestReconciler) Reconcile(
+	ctx context.Context, req ctrl.Request) (res ctrl.Result, err error) {
+	log := r.Log.WithValues("gaterequest", req.NamespacedName.String())
+	defer log.Info("reconciled")
+
+	gateRequestList := &iotv1alpha1.GateRequestList{}
+	if err := r.List(ctx, gateRequestList); err != nil {
+		return res, fmt.Errorf("listing GateRequests: %w", err)
+	}
+
+	// keep the newest completed requests of each Gate
+	items := gateRequestList.Items
+	sort.Slice(items, func(i, j int) bool {
+		return items[j].CreationTimestamp.Before(&items[i].CreationTimestamp)
+	})
+
+	requests := map[client.ObjectKey]int{}
+	for _, req := range items {
+		if !meta.IsStatusConditionTrue(req.Status.Conditions, iotv1alpha1.GateRequestCompleted) {
+			// TODO: Add reference check to find and report invalid references
+			continue
+		}
+
+		key := client.ObjectKey{
+			Name:      req.Spec.Gate.Name,
+			Namespace: req.Namespace,
+		}
+		requests[key]++
+		if requests[key] >= requestHistoryLimit {
+			if err := r.Delete(ctx, &req); err != nil {
+				return res, fmt.Errorf("garbage collecting GateRequest: %w", err)
+			}
+		}
+	}
+
+	return
+}
@@ -0,0 +1,333 @@
+package gates
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+)
+
+type GateReconciler struct {
+	client.Client
+	Log                    logr.Logger
+	Scheme                 *runtime.Scheme
+	DefaultRequeueInterval time.Duration
+	MovingRequeueInterval  time.Duration
+}
+
+func (r *GateReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(
+			&iotv1alpha1.Gate{},
+			// the Gate status is written on every reconcile,
+			// reconciling again right away would only read stale GateRequests.
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(
+			&source.Kind{
+				Type: &iotv1alpha1.GateRequest{},
+			},
+			handler.EnqueueRequestsFromMapFunc(func(o client.Object) []reconcile.Request {
+				req := o.(*iotv1alpha1.GateRequest)
+				return []reconcile.Request{
+					{
+						NamespacedName: client.ObjectKey{
+							Name:      req.Spec.Gate.Name,
+							Namespace: req.Namespace,
+						},
+					},
+				}
+			}),
+		).
+		Complete(r)
+}
+
+func (r *GateReconciler) Reconcile(
+	ctx context.Context, req ctrl.Request) (res ctrl.Result, err error) {
+	log := r.Log.WithValues("gate", req.NamespacedName.String())
+	defer log.Info("reconciled")
+
+	gate := &iotv1alpha1.Gate{}
+	if err := r.Get(ctx, req.NamespacedName, gate); err != nil {
+		return res, client.IgnoreNotFound(err)
+	}
+
+	// List requests
+	gateRequestList := &iotv1alpha1.GateRequestList{}
+	if err := r.List(ctx, gateRequestList, client.InNamespace(gate.Namespace)); err != nil {
+		return res, fmt.Errorf("listing GateRequests in namespace %s: %w", gate.Namespace, err)
+	}
+	var filteredGateRequests sortRequestsByCreation
+	for _, req := range gateRequestList.Items {
+		if req.Spec.Gate.Name != gate.Name {
+			continue
+		}
+
+		if meta.IsStatusConditionTrue(req.Status.Conditions, iotv1alpha1.GateRequestCompleted) {
+			continue
+		}
+
+		filteredGateRequests = append(filteredGateRequests, req)
+	}
+	sort.Sort(filteredGateRequests)
+
+	// Determine Client
+	dt := gate.Spec.DeviceType
+	dev := newDevice(gate)
+	if dev == nil {
+		meta.SetStatusCondition(&gate.Status.Conditions, metav1.Condition{
+			Type:   iotv1alpha1.GateReachable,
+			Status: metav1.ConditionFalse,
+			Reason: "UnkownDeviceType",
+			Message: fmt.Sprintf("Unkown device type %q, must be one of: [%s]",
+				dt, strings.Join(knownDeviceTypes, ", ")),
+		})
+		return r.updateStatus(ctx, gate)
+	}
+
+	// Status handling
+	state, err := r.state(ctx, dev, gate)
+	if err != nil {
+		return res, fmt.Errorf("reconciling %s: reading inputs: %w", dt, err)
+	}
+	meta.SetStatusCondition(&gate.Status.Conditions, metav1.Condition{
+		Type:    iotv1alpha1.GateReachable,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Connected",
+		Message: "connected to device",
+	})
+	gate.Status.State = state
+
+	// Request handling
+	// Requests are executed one after another,
+	// the gate only knows a single relay pulse to act on.
+	if len(filteredGateRequests) == 0 {
+		return r.updateStatus(ctx, gate)
+	}
+	if stop := findStopRequest(filteredGateRequests); stop != nil {
+		// stop requests take effect right away,
+		// instead of waiting for earlier requests to finish.
+		updated, err := r.handleStopRequest(ctx, dev, gate, filteredGateRequests, stop)
+		if err != nil {
+			return res, fmt.Errorf("reconciling %s: %w", dt, err)
+		}
+		return r.updateStatus(ctx, gate, updated...)
+	}
+	request := &filteredGateRequests[0]
+	if err := r.handleRequest(ctx, dev, gate, request); err != nil {
+		return res, fmt.Errorf("reconciling %s: %w", dt, err)
+	}
+	return r.updateStatus(ctx, gate, request)
+}
+
+// Derives the gate state from its contact inputs.
+func (r *GateReconciler) state(
+	ctx context.Context, dev device, gate *iotv1alpha1.Gate,
+) (iotv1alpha1.GateState, error) {
+	closed, err := dev.Input(ctx, gate.Spec.ClosedInput)
+	if err != nil {
+		return "", err
+	}
+	if closed {
+		return iotv1alpha1.GateStateClosed, nil
+	}
+
+	if gate.Spec.OpenInput == nil {
+		return iotv1alpha1.GateStateOpen, nil
+	}
+	open, err := dev.Input(ctx, *gate.Spec.OpenInput)
+	if err != nil {
+		return "", err
+	}
+	if open {
+		return iotv1alpha1.GateStateOpen, nil
+	}
+	return iotv1alpha1.GateStateIntermediate, nil
+}
+
+func (r *GateReconciler) updateStatus(
+	ctx context.Context, gate *iotv1alpha1.Gate,
+	requests ...*iotv1alpha1.GateRequest,
+) (res ctrl.Result, err error) {
+	gate.Status.ObservedGeneration = gate.Generation
+	if err := r.Status().Update(ctx, gate); err != nil {
+		return res, fmt.Errorf("updating Gate status: %w", err)
+	}
+
+	// always get a new status every now and then
+	res.RequeueAfter = r.DefaultRequeueInterval
+	if err := r.updateRequests(ctx, requests...); err != nil {
+		return res, err
+	}
+	for _, request := range requests {
+		if request.Status.Phase == iotv1alpha1.GateRequestPhaseMoving {
+			// poll a bit more frequently while moving
+			res.RequeueAfter = r.MovingRequeueInterval
+		}
+	}
+	return
+}
+
+func (r *GateReconciler) updateRequests(
+	ctx context.Context, requests ...*iotv1alpha1.GateRequest,
+) error {
+	for _, request := range requests {
+		request.Status.ObservedGeneration = request.Generation
+		if err := r.Status().Update(ctx, request); err != nil {
+			return fmt.Errorf("updating GateRequest status: %w", err)
+		}
+	}
+	return nil
+}
+
+// Returns the first Stop request or nil.
+func findStopRequest(requests []iotv1alpha1.GateRequest) *iotv1alpha1.GateRequest {
+	for i := range requests {
+		if requests[i].Spec.Action == iotv1alpha1.GateActionStop {
+			return &requests[i]
+		}
+	}
+	return nil
+}
+
+// Stops the gate, if an earlier request is still moving it.
+// The gate state can't tell a moving gate from one stopped halfway,
+// so the relay is only pulsed while a request is in progress.
+func (r *GateReconciler) handleStopRequest(
+	ctx context.Context, dev device,
+	gate *iotv1alpha1.Gate,
+	requests []iotv1alpha1.GateRequest,
+	stop *iotv1alpha1.GateRequest,
+) ([]*iotv1alpha1.GateRequest, error) {
+	var moving *iotv1alpha1.GateRequest
+	for i := range requests {
+		req := &requests[i]
+		if req.Status.Phase == iotv1alpha1.GateRequestPhaseMoving &&
+			req.Status.StartTime != nil &&
+			time.Since(req.Status.StartTime.Time) <= gate.Spec.TravelTime.Duration {
+			moving = req
+			break
+		}
+	}
+
+	if moving == nil {
+		completeRequest(stop, "NotMoving", "gate was not moving")
+		return []*iotv1alpha1.GateRequest{stop}, nil
+	}
+
+	// Complete the requests before pulsing,
+	// so a stale or failed update can't pulse the relay a second time
+	// and start the motor again.
+	completeRequest(moving, "Stopped",
+		fmt.Sprintf("stopped by GateRequest %s", stop.Name))
+	completeRequest(stop, "Stopped", "gate stopped")
+	if err := r.updateRequests(ctx, moving, stop); err != nil {
+		return nil, err
+	}
+
+	// a pulse while moving stops the gate motor
+	if err := dev.Pulse(ctx); err != nil {
+		failRequest(stop, "PulseFailed", fmt.Sprintf("pulsing relay: %v", err))
+	}
+	return []*iotv1alpha1.GateRequest{moving, stop}, nil
+}
+
+func (r *GateReconciler) handleRequest(
+	ctx context.Context, dev device,
+	gate *iotv1alpha1.Gate,
+	req *iotv1alpha1.GateRequest,
+) error {
+	targetState := iotv1alpha1.GateStateOpen
+	if req.Spec.Action == iotv1alpha1.GateActionClose {
+		targetState = iotv1alpha1.GateStateClosed
+	}
+	if gate.Status.State == targetState {
+		completeRequest(req, "AtPosition", "end position reached")
+		return nil
+	}
+
+	if req.Status.StartTime == nil {
+		// Persist the request before pulsing,
+		// so a stale or failed update can't pulse the relay a second time,
+		// which would stop or reverse the gate.
+		now := metav1.Now()
+		req.Status.StartTime = &now
+		setMoving(req)
+		if err := r.updateRequests(ctx, req); err != nil {
+			return err
+		}
+		if err := dev.Pulse(ctx); err != nil {
+			// the gate might have been triggered anyway, don't retry.
+			failRequest(req, "PulseFailed", fmt.Sprintf("pulsing relay: %v", err))
+		}
+		return nil
+	}
+
+	if time.Since(req.Status.StartTime.Time) > gate.Spec.TravelTime.Duration {
+		failRequest(req, "TravelTimeout", fmt.Sprintf(
+			"end position not reached within %s", gate.Spec.TravelTime.Duration))
+		return nil
+	}
+	setMoving(req)
+	return nil
+}
+
+func setMoving(req *iotv1alpha1.GateRequest) {
+	meta.SetStatusCondition(&req.Status.Conditions, metav1.Condition{
+		Type:    iotv1alpha1.GateRequestCompleted,
+		Status:  metav1.ConditionFalse,
+		Reason:  "Moving",
+		Message: "moving gate to end position",
+	})
+	req.Status.Phase = iotv1alpha1.GateRequestPhaseMoving
+}
+
+func failRequest(req *iotv1alpha1.GateRequest, reason, message string) {
+	meta.SetStatusCondition(&req.Status.Conditions, metav1.Condition{
+		Type:    iotv1alpha1.GateRequestCompleted,
+		Status:  metav1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	})
+	req.Status.Phase = iotv1alpha1.GateRequestPhaseFailed
+}
+
+func completeRequest(req *iotv1alpha1.GateRequest, reason, message string) {
+	meta.SetStatusCondition(&req.Status.Conditions, metav1.Condition{
+		Type:    iotv1alpha1.GateRequestCompleted,
+		Status:  metav1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	})
+	req.Status.Phase = iotv1alpha1.GateRequestPhaseCompleted
+}
+
+// Sorts requests by creation timestamp.
+type sortRequestsByCreation []iotv1alpha1.GateRequest
+
+func (p sortRequestsByCreation) Len() int {
+	return len(p)
+}
+
+func (p sortRequestsByCreation) Less(i, j int) bool {
+	return p[i].GetCreationTimestamp().UTC().Before(p[j].GetCreationTimestamp().UTC())
+}
+
+func (p sortRequestsByCreation) Swap(i, j int) {
+	p[i], p[j] = p[j], p[i]
+}
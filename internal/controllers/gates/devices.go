@@ -0,0 +1,96 @@
+package gates
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+	"github.com/thetechnick/iot-operator/internal/clients"
+	"github.com/thetechnick/iot-operator/internal/clients/shellyrelayclient"
+	"github.com/thetechnick/iot-operator/internal/clients/shellyrpcclient"
+)
+
+const (
+	shellyRelay     = "ShellyRelay"
+	shellyPlusRelay = "ShellyPlusRelay"
+)
+
+// Device independent interface to control a gate.
+type device interface {
+	// Returns true if the given input is active.
+	Input(ctx context.Context, input int) (bool, error)
+	// Pulses the relay to trigger the gate motor.
+	Pulse(ctx context.Context) error
+}
+
+// Returns the device implementation for the given Gate
+// or nil, if the device type is unknown.
+func newDevice(gate *iotv1alpha1.Gate) device {
+	endpoint := clients.WithEndpoint(gate.Spec.Endpoint.URL)
+	switch gate.Spec.DeviceType {
+	case shellyRelay:
+		return &shellyRelayDevice{
+			c:             shellyrelayclient.NewClient(endpoint),
+			channel:       gate.Spec.Channel,
+			pulseDuration: gate.Spec.PulseDuration.Duration,
+		}
+	case shellyPlusRelay:
+		return &shellyPlusRelayDevice{
+			c:             shellyrpcclient.NewClient(endpoint),
+			channel:       gate.Spec.Channel,
+			pulseDuration: gate.Spec.PulseDuration.Duration,
+		}
+	}
+	return nil
+}
+
+// List of all supported device types for error reporting.
+var knownDeviceTypes = []string{
+	shellyRelay,
+	shellyPlusRelay,
+}
+
+// Shelly Gen1 relays, e.g. Shelly 1 with the add-on inputs.
+type shellyRelayDevice struct {
+	c             *shellyrelayclient.Client
+	channel       int
+	pulseDuration time.Duration
+}
+
+func (d *shellyRelayDevice) Input(ctx context.Context, input int) (bool, error) {
+	status, err := d.c.Status(ctx)
+	if err != nil {
+		return false, err
+	}
+	if input < 0 || input >= len(status.Inputs) {
+		return false, fmt.Errorf(
+			"input %d not found, device has %d inputs", input, len(status.Inputs))
+	}
+	return status.Inputs[input].Input == 1, nil
+}
+
+func (d *shellyRelayDevice) Pulse(ctx context.Context) error {
+	_, err := d.c.Pulse(ctx, d.channel, d.pulseDuration)
+	return err
+}
+
+// Shelly Gen2 relays, e.g. Shelly Plus 1.
+type shellyPlusRelayDevice struct {
+	c             *shellyrpcclient.Client
+	channel       int
+	pulseDuration time.Duration
+}
+
+func (d *shellyPlusRelayDevice) Input(ctx context.Context, input int) (bool, error) {
+	status, err := d.c.InputGetStatus(ctx, input)
+	if err != nil {
+		return false, err
+	}
+	return status.State, nil
+}
+
+func (d *shellyPlusRelayDevice) Pulse(ctx context.Context) error {
+	_, err := d.c.SwitchPulse(ctx, d.channel, d.pulseDuration)
+	return err
+}
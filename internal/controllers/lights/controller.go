@@ -0,0 +1,107 @@
+package lights
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+)
+
+type LightReconciler struct {
+	client.Client
+	Log                    logr.Logger
+	Scheme                 *runtime.Scheme
+	DefaultRequeueInterval time.Duration
+}
+
+func (r *LightReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&iotv1alpha1.Light{}).
+		Complete(r)
+}
+
+func (r *LightReconciler) Reconcile(
+	ctx context.Context, req ctrl.Request) (res ctrl.Result, err error) {
+	log := r.Log.WithValues("light", req.NamespacedName.String())
+	defer log.Info("reconciled")
+
+	light := &iotv1alpha1.Light{}
+	if err := r.Get(ctx, req.NamespacedName, light); err != nil {
+		return res, client.IgnoreNotFound(err)
+	}
+
+	// Determine Client
+	dt := light.Spec.DeviceType
+	dev := newDevice(light)
+	if dev == nil {
+		meta.SetStatusCondition(&light.Status.Conditions, metav1.Condition{
+			Type:   iotv1alpha1.LightReachable,
+			Status: metav1.ConditionFalse,
+			Reason: "UnkownDeviceType",
+			Message: fmt.Sprintf("Unkown device type %q, must be one of: [%s]",
+				dt, strings.Join(knownDeviceTypes, ", ")),
+		})
+		return r.updateStatus(ctx, light)
+	}
+
+	// Status handling
+	status, err := dev.Status(ctx)
+	if err != nil {
+		return res, fmt.Errorf("reconciling %s: reading status: %w", dt, err)
+	}
+	meta.SetStatusCondition(&light.Status.Conditions, metav1.Condition{
+		Type:    iotv1alpha1.LightReachable,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Connected",
+		Message: "connected to device",
+	})
+
+	// Desired state handling
+	// brightness and color may be adjusted from the Shelly app,
+	// pushing them on every poll would undo those adjustments.
+	if light.Status.ObservedGeneration != light.Generation && hasDesiredState(light.Spec) {
+		status, err = dev.Set(ctx, light.Spec)
+		if err != nil {
+			return res, fmt.Errorf("reconciling %s: changing settings: %w", dt, err)
+		}
+	}
+
+	if status.On {
+		light.Status.State = iotv1alpha1.LightStateOn
+	} else {
+		light.Status.State = iotv1alpha1.LightStateOff
+	}
+	light.Status.Brightness = status.Brightness
+	light.Status.ColorTemperature = status.ColorTemperature
+	light.Status.Color = status.Color
+	return r.updateStatus(ctx, light)
+}
+
+func (r *LightReconciler) updateStatus(
+	ctx context.Context, light *iotv1alpha1.Light,
+) (res ctrl.Result, err error) {
+	light.Status.ObservedGeneration = light.Generation
+	if err := r.Status().Update(ctx, light); err != nil {
+		return res, fmt.Errorf("updating Light status: %w", err)
+	}
+
+	// always get a new status every now and then
+	res.RequeueAfter = r.DefaultRequeueInterval
+	return
+}
+
+func hasDesiredState(spec iotv1alpha1.LightSpec) bool {
+	return len(spec.State) > 0 ||
+		spec.Brightness != nil ||
+		spec.ColorTemperature != nil ||
+		spec.Color != nil
+}
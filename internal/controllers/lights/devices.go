@@ -0,0 +1,153 @@
+package lights
+
+import (
+	"context"
+
+	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+	"github.com/thetechnick/iot-operator/internal/clients"
+	"github.com/thetechnick/iot-operator/internal/clients/shellylightclient"
+)
+
+const (
+	shellyDimmer = "ShellyDimmer"
+	shellyRGBW2  = "ShellyRGBW2"
+)
+
+// Device independent interface to control a light.
+type device interface {
+	Status(ctx context.Context) (deviceStatus, error)
+	Set(ctx context.Context, spec iotv1alpha1.LightSpec) (deviceStatus, error)
+}
+
+// Device independent light status.
+type deviceStatus struct {
+	On bool
+	// Brightness in percent.
+	Brightness int
+	// Color temperature in Kelvin, 0 if not supported.
+	ColorTemperature int
+	// Color, nil if not supported.
+	Color *iotv1alpha1.LightColor
+}
+
+// Returns the device implementation for the given Light
+// or nil, if the device type is unknown.
+func newDevice(light *iotv1alpha1.Light) device {
+	endpoint := clients.WithEndpoint(light.Spec.Endpoint.URL)
+	switch light.Spec.DeviceType {
+	case shellyDimmer:
+		return &shellyDimmerDevice{
+			c:       shellylightclient.NewClient(endpoint),
+			channel: light.Spec.Channel,
+		}
+	case shellyRGBW2:
+		return &shellyRGBW2Device{
+			c:       shellylightclient.NewClient(endpoint),
+			channel: light.Spec.Channel,
+		}
+	}
+	return nil
+}
+
+// List of all supported device types for error reporting.
+var knownDeviceTypes = []string{
+	shellyDimmer,
+	shellyRGBW2,
+}
+
+// Shelly Gen1 white lights, e.g. Shelly Dimmer 2 and Duo.
+type shellyDimmerDevice struct {
+	c       *shellylightclient.Client
+	channel int
+}
+
+func (d *shellyDimmerDevice) Status(ctx context.Context) (deviceStatus, error) {
+	status, err := d.c.Light(ctx, d.channel)
+	if err != nil {
+		return deviceStatus{}, err
+	}
+	return d.convertStatus(status), nil
+}
+
+func (d *shellyDimmerDevice) Set(
+	ctx context.Context, spec iotv1alpha1.LightSpec,
+) (deviceStatus, error) {
+	status, err := d.c.SetLight(ctx, d.channel, shellylightclient.LightParams{
+		On:         desiredOn(spec),
+		Brightness: spec.Brightness,
+		Temp:       spec.ColorTemperature,
+	})
+	if err != nil {
+		return deviceStatus{}, err
+	}
+	return d.convertStatus(status), nil
+}
+
+func (d *shellyDimmerDevice) convertStatus(
+	status shellylightclient.LightStatus,
+) deviceStatus {
+	return deviceStatus{
+		On:               status.IsOn,
+		Brightness:       status.Brightness,
+		ColorTemperature: status.Temp,
+	}
+}
+
+// Shelly Gen1 RGBW2 in color mode.
+type shellyRGBW2Device struct {
+	c       *shellylightclient.Client
+	channel int
+}
+
+func (d *shellyRGBW2Device) Status(ctx context.Context) (deviceStatus, error) {
+	status, err := d.c.Color(ctx, d.channel)
+	if err != nil {
+		return deviceStatus{}, err
+	}
+	return d.convertStatus(status), nil
+}
+
+func (d *shellyRGBW2Device) Set(
+	ctx context.Context, spec iotv1alpha1.LightSpec,
+) (deviceStatus, error) {
+	params := shellylightclient.ColorParams{
+		On:   desiredOn(spec),
+		Gain: spec.Brightness,
+	}
+	if c := spec.Color; c != nil {
+		params.Red = &c.Red
+		params.Green = &c.Green
+		params.Blue = &c.Blue
+		params.White = &c.White
+	}
+
+	status, err := d.c.SetColor(ctx, d.channel, params)
+	if err != nil {
+		return deviceStatus{}, err
+	}
+	return d.convertStatus(status), nil
+}
+
+func (d *shellyRGBW2Device) convertStatus(
+	status shellylightclient.ColorStatus,
+) deviceStatus {
+	return deviceStatus{
+		On:         status.IsOn,
+		Brightness: status.Gain,
+		Color: &iotv1alpha1.LightColor{
+			Red:   status.Red,
+			Green: status.Green,
+			Blue:  status.Blue,
+			White: status.White,
+		},
+	}
+}
+
+// Returns the desired on/off state or nil, if not specified.
+func desiredOn(spec iotv1alpha1.LightSpec) *bool {
+	if len(spec.State) == 0 {
+		return nil
+	}
+	on := spec.State == iotv1alpha1.LightStateOn
+	return &on
+}
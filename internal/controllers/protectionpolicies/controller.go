@@ -0,0 +1,271 @@
+package protectionpolicies
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+	"github.com/thetechnick/iot-operator/internal/sensors"
+)
+
+// Priority of RollerShutterRequests created by ProtectionPolicies.
+const protectionRequestPriority = 1000
+
+type ProtectionPolicyReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+func (r *ProtectionPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(
+			&iotv1alpha1.ProtectionPolicy{},
+			// the sensor is polled via RequeueAfter,
+			// writing each reading to the status must not trigger another poll.
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Owns(&iotv1alpha1.RollerShutterRequest{}).
+		Complete(r)
+}
+
+func (r *ProtectionPolicyReconciler) Reconcile(
+	ctx context.Context, req ctrl.Request) (res ctrl.Result, err error) {
+	log := r.Log.WithValues("protectionpolicy", req.NamespacedName.String())
+	defer log.Info("reconciled")
+
+	policy := &iotv1alpha1.ProtectionPolicy{}
+	if err := r.Get(ctx, req.NamespacedName, policy); err != nil {
+		return res, client.IgnoreNotFound(err)
+	}
+
+	// Sensor handling
+	read := r.readSensor(ctx, policy)
+	if !read && policy.Status.Phase == iotv1alpha1.ProtectionPolicyPhaseActive {
+		// Without readings we can't tell whether it's safe to deactivate,
+		// so the calm down period only starts once the sensor is back.
+		now := metav1.Now()
+		policy.Status.LastTriggeredTime = &now
+	}
+
+	var calmDown time.Duration
+	if lastTriggered := policy.Status.LastTriggeredTime; lastTriggered != nil {
+		calmDown = time.Until(lastTriggered.Add(policy.Spec.CalmDownPeriod.Duration))
+	}
+
+	// Request handling
+	if calmDown > 0 {
+		condition := metav1.Condition{
+			Type:   iotv1alpha1.ProtectionPolicyActive,
+			Status: metav1.ConditionTrue,
+			Reason: "ThresholdExceeded",
+			Message: fmt.Sprintf("sensor reading exceeded threshold of %s",
+				policy.Spec.Threshold.String()),
+		}
+		if !read {
+			condition.Reason = "SensorUnreachable"
+			condition.Message = "sensor can't be read, staying active"
+		}
+		meta.SetStatusCondition(&policy.Status.Conditions, condition)
+		policy.Status.Phase = iotv1alpha1.ProtectionPolicyPhaseActive
+
+		if err := r.ensureRequests(ctx, policy); err != nil {
+			return res, err
+		}
+	} else {
+		meta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+			Type:    iotv1alpha1.ProtectionPolicyActive,
+			Status:  metav1.ConditionFalse,
+			Reason:  "BelowThreshold",
+			Message: "sensor reading is below threshold",
+		})
+		policy.Status.Phase = iotv1alpha1.ProtectionPolicyPhaseInactive
+
+		if err := r.cleanupRequests(ctx, policy); err != nil {
+			return res, err
+		}
+	}
+
+	// Handle status
+	policy.Status.ObservedGeneration = policy.Generation
+	if err := r.Status().Update(ctx, policy); err != nil {
+		return res, fmt.Errorf("updating ProtectionPolicy status: %w", err)
+	}
+
+	res.RequeueAfter = policy.Spec.PollInterval.Duration
+	if calmDown > 0 && calmDown < res.RequeueAfter {
+		res.RequeueAfter = calmDown
+	}
+	return
+}
+
+// Reads the sensor and records the reading in the policy status.
+// Returns false when the sensor can't be read.
+func (r *ProtectionPolicyReconciler) readSensor(
+	ctx context.Context, policy *iotv1alpha1.ProtectionPolicy,
+) bool {
+	reading, err := sensors.Read(ctx, r.Client, policy.Namespace, policy.Spec.Source)
+	if err != nil {
+		meta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+			Type:    iotv1alpha1.ProtectionPolicySensorReachable,
+			Status:  metav1.ConditionFalse,
+			Reason:  "ReadError",
+			Message: err.Error(),
+		})
+		return false
+	}
+
+	value, err := sensors.ParseQuantity(reading)
+	if err != nil {
+		meta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+			Type:    iotv1alpha1.ProtectionPolicySensorReachable,
+			Status:  metav1.ConditionFalse,
+			Reason:  "InvalidReading",
+			Message: err.Error(),
+		})
+		return false
+	}
+
+	meta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+		Type:    iotv1alpha1.ProtectionPolicySensorReachable,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Connected",
+		Message: "connected to sensor",
+	})
+
+	now := metav1.Now()
+	policy.Status.LastReading = reading
+	policy.Status.LastReadingTime = &now
+	if value.Cmp(policy.Spec.Threshold) > 0 {
+		policy.Status.LastTriggeredTime = &now
+	}
+	return true
+}
+
+// Ensures that every protected RollerShutter has a RollerShutterRequest
+// moving it into the protected position.
+func (r *ProtectionPolicyReconciler) ensureRequests(
+	ctx context.Context, policy *iotv1alpha1.ProtectionPolicy,
+) error {
+	selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.RollerShutterSelector)
+	if err != nil {
+		return fmt.Errorf("parsing RollerShutter selector: %w", err)
+	}
+
+	rollerShutterList := &iotv1alpha1.RollerShutterList{}
+	if err := r.List(ctx, rollerShutterList,
+		client.InNamespace(policy.Namespace),
+		client.MatchingLabelsSelector{Selector: selector},
+	); err != nil {
+		return fmt.Errorf("listing RollerShutters: %w", err)
+	}
+
+	requestList, err := r.listRequests(ctx, policy)
+	if err != nil {
+		return err
+	}
+	existing := map[string]struct{}{}
+	for i := range requestList.Items {
+		req := &requestList.Items[i]
+		if !retracted(req) {
+			// the shutter might not be in the protected position,
+			// replace the request to try again.
+			if err := r.Delete(ctx, req); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("deleting RollerShutterRequest: %w", err)
+			}
+			continue
+		}
+		existing[req.Spec.RollerShutter.Name] = struct{}{}
+	}
+
+	for _, rollerShutter := range rollerShutterList.Items {
+		if _, ok := existing[rollerShutter.Name]; ok {
+			continue
+		}
+
+		req := &iotv1alpha1.RollerShutterRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: policy.Name + "-",
+				Namespace:    policy.Namespace,
+				Labels: map[string]string{
+					iotv1alpha1.ProtectionPolicyLabel: policy.Name,
+				},
+			},
+			Spec: iotv1alpha1.RollerShutterRequestSpec{
+				Position: policy.Spec.Position,
+				RollerShutter: corev1.LocalObjectReference{
+					Name: rollerShutter.Name,
+				},
+				Priority: protectionRequestPriority,
+			},
+		}
+		if err := controllerutil.SetControllerReference(policy, req, r.Scheme); err != nil {
+			return fmt.Errorf("setting controller reference: %w", err)
+		}
+		if err := r.Create(ctx, req); err != nil {
+			return fmt.Errorf("creating RollerShutterRequest: %w", err)
+		}
+	}
+	return nil
+}
+
+// Returns false if the request was rejected or preempted,
+// before moving the shutter into the protected position.
+func retracted(req *iotv1alpha1.RollerShutterRequest) bool {
+	if req.Status.Phase == iotv1alpha1.RollerShutterRequestPhaseRejected {
+		return false
+	}
+	c := meta.FindStatusCondition(
+		req.Status.Conditions, iotv1alpha1.RollerShutterRequestCompleted)
+	return c == nil || c.Status != metav1.ConditionTrue || c.Reason != "Preempted"
+}
+
+// Removes RollerShutterRequests of the policy,
+// so protected RollerShutters are moved again on the next activation.
+func (r *ProtectionPolicyReconciler) cleanupRequests(
+	ctx context.Context, policy *iotv1alpha1.ProtectionPolicy,
+) error {
+	requestList, err := r.listRequests(ctx, policy)
+	if err != nil {
+		return err
+	}
+
+	for i := range requestList.Items {
+		req := &requestList.Items[i]
+		if !meta.IsStatusConditionTrue(
+			req.Status.Conditions, iotv1alpha1.RollerShutterRequestCompleted) {
+			// let the shutter finish moving
+			continue
+		}
+
+		if err := r.Delete(ctx, req); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("deleting RollerShutterRequest: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *ProtectionPolicyReconciler) listRequests(
+	ctx context.Context, policy *iotv1alpha1.ProtectionPolicy,
+) (*iotv1alpha1.RollerShutterRequestList, error) {
+	requestList := &iotv1alpha1.RollerShutterRequestList{}
+	if err := r.List(ctx, requestList,
+		client.InNamespace(policy.Namespace),
+		client.MatchingLabels{iotv1alpha1.ProtectionPolicyLabel: policy.Name},
+	); err != nil {
+		return nil, fmt.Errorf("listing RollerShutterRequests: %w", err)
+	}
+	return requestList, nil
+}
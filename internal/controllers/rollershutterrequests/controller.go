@@ -5,14 +5,19 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+	"github.com/thetechnick/iot-operator/internal/tracing"
 )
 
+var tracer = tracing.Tracer("github.com/thetechnick/iot-operator/internal/controllers/rollershutterrequests")
+
 const requestHistoryLimit = 5
 
 type RollerShutterRequestReconciler struct {
@@ -32,6 +37,16 @@ func (r *RollerShutterRequestReconciler) Reconcile(
 	log := r.Log.WithValues("rollershutterrequest", req.NamespacedName.String())
 	defer log.Info("reconciled")
 
+	ctx, span := tracer.Start(ctx, "RollerShutterRequest.Reconcile", trace.WithAttributes(
+		tracing.ObjectAttributes("rollershutterrequest", req.Namespace, req.Name)...))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	request := &iotv1alpha1.RollerShutterRequest{}
+	if err := r.Get(ctx, req.NamespacedName, request); err == nil {
+		span.SetAttributes(attribute.String(
+			"iot.rollershutter.name", request.Spec.RollerShutter.Name))
+	}
+
 	rollerShutterRequestList := &iotv1alpha1.RollerShutterRequestList{}
 	if err := r.List(ctx, rollerShutterRequestList); err != nil {
 		return res, fmt.Errorf("listing RollerShutterRequests: %w", err)
@@ -3,35 +3,91 @@ package rollershutters
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
-	"github.com/thetechnick/iot-operator/internal/clients"
-	"github.com/thetechnick/iot-operator/internal/clients/shelly25rollerclient"
+	"github.com/thetechnick/iot-operator/internal/clients/mqttclient"
+	"github.com/thetechnick/iot-operator/internal/clients/shellywsclient"
+	"github.com/thetechnick/iot-operator/internal/shellywebhook"
+	"github.com/thetechnick/iot-operator/internal/tracing"
 )
 
+var tracer = tracing.Tracer("github.com/thetechnick/iot-operator/internal/controllers/rollershutters")
+
 type RollerShutterReconciler struct {
 	client.Client
-	Log                    logr.Logger
-	Scheme                 *runtime.Scheme
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// Uncached reader, used to read Secrets.
+	APIReader              client.Reader
 	DefaultRequeueInterval time.Duration
 	MovingRequeueInterval  time.Duration
+	// Optional, enables devices to communicate via MQTT.
+	MQTT *mqttclient.Manager
+	// Optional, enqueues RollerShutters when their device calls back.
+	Webhook *shellywebhook.Receiver
+	// Optional, subscribes to notifications of Shelly Gen2 devices.
+	Websockets *shellywsclient.Manager
+
+	identities   identityCache
+	temperatures readingCache
 }
 
 func (r *RollerShutterReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&iotv1alpha1.RollerShutter{}).
+	b := ctrl.NewControllerManagedBy(mgr)
+	if r.MQTT != nil {
+		// status updates pushed via MQTT
+		b = b.Watches(
+			&source.Channel{Source: r.MQTT.Events()},
+			&handler.EnqueueRequestForObject{},
+		)
+	}
+	if r.Websockets != nil {
+		// notifications from Shelly Gen2 devices
+		b = b.Watches(
+			&source.Channel{Source: r.Websockets.Events()},
+			&handler.EnqueueRequestForObject{},
+		)
+	}
+	if r.Webhook != nil {
+		// state changes reported by device callbacks
+		b = b.Watches(
+			&source.Channel{Source: r.Webhook.Events()},
+			&handler.EnqueueRequestForObject{},
+		)
+	}
+	return b.
+		For(
+			&iotv1alpha1.RollerShutter{},
+			// position and health are written to the status on every poll,
+			// polling is paced by the default and moving requeue intervals.
+			// Labels select ProtectionPolicies, so changes take effect right away.
+			builder.WithPredicates(predicate.Or(
+				predicate.GenerationChangedPredicate{},
+				predicate.LabelChangedPredicate{},
+			)),
+		).
 		Watches(
 			&source.Kind{
 				Type: &iotv1alpha1.RollerShutterRequest{},
@@ -48,27 +104,99 @@ func (r *RollerShutterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 				}
 			}),
 		).
+		Watches(
+			&source.Kind{
+				Type: &iotv1alpha1.ProtectionPolicy{},
+			},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueAllInNamespace),
+			builder.WithPredicates(predicate.Funcs{
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					// ProtectionPolicies update their status on every poll,
+					// only activation and spec changes are relevant.
+					oldPolicy := e.ObjectOld.(*iotv1alpha1.ProtectionPolicy)
+					newPolicy := e.ObjectNew.(*iotv1alpha1.ProtectionPolicy)
+					return oldPolicy.Generation != newPolicy.Generation ||
+						oldPolicy.Status.Phase != newPolicy.Status.Phase
+				},
+			}),
+		).
+		Watches(
+			&source.Kind{
+				Type: &iotv1alpha1.FirmwareUpdatePolicy{},
+			},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueAllInNamespace),
+			builder.WithPredicates(predicate.Funcs{
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					// only starting and finishing updates lock and unlock shutters.
+					oldPolicy := e.ObjectOld.(*iotv1alpha1.FirmwareUpdatePolicy)
+					newPolicy := e.ObjectNew.(*iotv1alpha1.FirmwareUpdatePolicy)
+					return !reflect.DeepEqual(updatingDevices(oldPolicy), updatingDevices(newPolicy))
+				},
+			}),
+		).
 		Complete(r)
 }
 
-const shelly25Roller = "Shelly25Roller"
+// Returns the names of devices the policy is updating.
+func updatingDevices(policy *iotv1alpha1.FirmwareUpdatePolicy) []string {
+	var names []string
+	for _, d := range policy.Status.Devices {
+		if d.Phase == iotv1alpha1.FirmwareUpdatePhaseUpdating {
+			names = append(names, d.Name)
+		}
+	}
+	return names
+}
+
+// Enqueues all RollerShutters in the namespace of the given object.
+func (r *RollerShutterReconciler) enqueueAllInNamespace(o client.Object) []reconcile.Request {
+	rollerShutterList := &iotv1alpha1.RollerShutterList{}
+	if err := r.List(context.Background(), rollerShutterList, client.InNamespace(o.GetNamespace())); err != nil {
+		r.Log.Error(err, "listing RollerShutters", "namespace", o.GetNamespace())
+		return nil
+	}
+
+	reqs := make([]reconcile.Request, len(rollerShutterList.Items))
+	for i := range rollerShutterList.Items {
+		reqs[i] = reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&rollerShutterList.Items[i]),
+		}
+	}
+	return reqs
+}
 
 func (r *RollerShutterReconciler) Reconcile(
 	ctx context.Context, req ctrl.Request) (res ctrl.Result, err error) {
 	log := r.Log.WithValues("rollershutter", req.NamespacedName.String())
 	defer log.Info("reconciled")
 
+	ctx, span := tracer.Start(ctx, "RollerShutter.Reconcile", trace.WithAttributes(
+		tracing.ObjectAttributes("rollershutter", req.Namespace, req.Name)...))
+	defer func() { tracing.EndSpan(span, err) }()
+
 	rollerShutter := &iotv1alpha1.RollerShutter{}
-	if err := r.Get(ctx, req.NamespacedName, rollerShutter); err != nil {
-		return res, client.IgnoreNotFound(err)
+	if err := r.Get(ctx, req.NamespacedName, rollerShutter); errors.IsNotFound(err) {
+		if r.Websockets != nil {
+			r.Websockets.Remove(req.NamespacedName)
+		}
+		if r.MQTT != nil {
+			r.MQTT.Remove(req.NamespacedName)
+		}
+		r.identities.forget(req.NamespacedName)
+		deleteMetrics(req.NamespacedName)
+		return res, nil
+	} else if err != nil {
+		return res, err
 	}
+	r.ensureWebsocket(rollerShutter)
 
 	// List requests
 	rollerShutterRequestList := &iotv1alpha1.RollerShutterRequestList{}
 	if err := r.List(ctx, rollerShutterRequestList, client.InNamespace(rollerShutter.Namespace)); err != nil {
 		return res, fmt.Errorf("listing RollerShutterRequests in namespace %s: %w", rollerShutter.Namespace, err)
 	}
-	var filteredRollerShutterRequests sortRequestByCreationTimestamp
+	var filteredRollerShutterRequests sortRequestsByPriority
+	previousRequestPhases := map[string]iotv1alpha1.RollerShutterRequestPhase{}
 	for _, req := range rollerShutterRequestList.Items {
 		if req.Spec.RollerShutter.Name != rollerShutter.Name {
 			continue
@@ -79,43 +207,215 @@ func (r *RollerShutterReconciler) Reconcile(
 		}
 
 		filteredRollerShutterRequests = append(filteredRollerShutterRequests, req)
+		previousRequestPhases[req.Name] = req.Status.Phase
 	}
 	sort.Sort(filteredRollerShutterRequests)
-	var request *iotv1alpha1.RollerShutterRequest
-	if len(filteredRollerShutterRequests) > 0 {
-		request = &filteredRollerShutterRequests[0]
-	}
 
 	// Determine Client
 	dt := rollerShutter.Spec.DeviceType
-	switch dt {
-	case shelly25Roller:
-		if err := r.reconcileShelly25Roller(ctx, rollerShutter, request); err != nil {
+	if r.MQTT != nil && rollerShutter.Spec.Endpoint.MQTT == nil {
+		r.MQTT.Remove(client.ObjectKeyFromObject(rollerShutter))
+	}
+	dev, err := newDevice(ctx, r.Client, r.APIReader, r.MQTT, rollerShutter)
+	if isMQTTConnectionError(err) {
+		r.setUnreachable(rollerShutter, "Unreachable", err.Error())
+		if _, err := r.updateStatus(ctx, rollerShutter, nil); err != nil {
+			return res, err
+		}
+		return res, fmt.Errorf("reconciling %s: %w", dt, err)
+	}
+	if err != nil {
+		r.setUnreachable(rollerShutter, "InvalidDeviceProfile", err.Error())
+		return r.updateStatus(ctx, rollerShutter, nil)
+	}
+	if dev == nil {
+		r.setUnreachable(rollerShutter, "UnkownDeviceType",
+			fmt.Sprintf("Unkown device type %q, must be one of: [%s]",
+				dt, strings.Join(knownDeviceTypes, ", ")))
+		return r.updateStatus(ctx, rollerShutter, nil)
+	}
+
+	// Identity handling
+	id, verified, err := r.verifyIdentity(ctx, rollerShutter)
+	if err != nil {
+		r.setUnreachable(rollerShutter, "Unreachable", "device did not respond")
+		if _, err := r.updateStatus(ctx, rollerShutter, nil); err != nil {
+			return res, err
+		}
+		return res, fmt.Errorf("reconciling %s: %w", dt, err)
+	}
+	if !verified {
+		// don't move someone else's shutter, requests stay pending.
+		return r.updateStatus(ctx, rollerShutter, nil)
+	}
+
+	// Status handling
+	previousStatus := rollerShutter.Status.DeepCopy()
+	status, err := dev.Status(ctx)
+	if err != nil {
+		r.setUnreachable(rollerShutter, "Unreachable", "device did not respond")
+		if _, err := r.updateStatus(ctx, rollerShutter, nil); err != nil {
+			return res, err
+		}
+		return res, fmt.Errorf("reconciling %s: reading status: %w", dt, err)
+	}
+	meta.SetStatusCondition(&rollerShutter.Status.Conditions, metav1.Condition{
+		Type:    iotv1alpha1.RollerShutterReachable,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Connected",
+		Message: "connected to device",
+	})
+	setDeviceStatus(rollerShutter, status)
+	r.recordStop(rollerShutter, previousStatus, status)
+	if err := reportDeviceInfo(ctx, rollerShutter, dev, id); err != nil {
+		// device info is informational, locks and requests still need handling.
+		log.Error(err, "reporting device info")
+	}
+	if rebooted(previousStatus, &rollerShutter.Status) {
+		// firmware may have changed, or the device was replaced,
+		// so verify it again before sending anything.
+		r.identities.forget(client.ObjectKeyFromObject(rollerShutter))
+		id, verified, err = r.verifyIdentity(ctx, rollerShutter)
+		if err != nil {
+			r.setUnreachable(rollerShutter, "Unreachable", "device did not respond")
+			if _, err := r.updateStatus(ctx, rollerShutter, nil); err != nil {
+				return res, err
+			}
+			return res, fmt.Errorf("reconciling %s: %w", dt, err)
+		}
+		if !verified {
+			return r.updateStatus(ctx, rollerShutter, nil)
+		}
+		rollerShutter.Status.DeviceInfo.Model = id.Model
+		rollerShutter.Status.DeviceInfo.Firmware = id.Firmware
+	}
+	if status.Phase == iotv1alpha1.RollerShutterPhaseIdle {
+		// don't reconfigure a moving motor
+		if err := reconcileSettings(ctx, rollerShutter, dev); err != nil {
+			log.Error(err, "reconciling settings")
+		}
+	}
+
+	// Manual override handling
+	detectManualOverride(rollerShutter, previousStatus, filteredRollerShutterRequests)
+	holdOff := reportManualOverride(rollerShutter)
+
+	// Lock handling
+	locks, err := r.locks(ctx, rollerShutter)
+	if err != nil {
+		return res, err
+	}
+	reportLocks(rollerShutter, locks)
+
+	// Request handling
+	var updatedRequests []*iotv1alpha1.RollerShutterRequest
+	var request *iotv1alpha1.RollerShutterRequest
+	for i := range filteredRollerShutterRequests {
+		req := &filteredRollerShutterRequests[i]
+		if l, blocked := blockingLock(locks, req); blocked {
+			if req.Status.Phase == iotv1alpha1.RollerShutterRequestPhaseMoving &&
+				rollerShutter.Status.Phase != iotv1alpha1.RollerShutterPhaseIdle {
+				// lock was placed while the shutter is moving,
+				// e.g. a door was opened while closing.
+				if _, err := dev.Stop(ctx); err != nil {
+					log.Error(err, "stopping shutter")
+				}
+			}
+			lockRequest(req, l)
+			updatedRequests = append(updatedRequests, req)
+			continue
+		}
+
+		if req.Spec.Automated && holdOff > 0 {
+			holdManualOverride(req, rollerShutter)
+			updatedRequests = append(updatedRequests, req)
+			continue
+		}
+
+		request = req
+		updatedRequests = append(updatedRequests, req)
+		break
+	}
+	if request != nil {
+		for i := range filteredRollerShutterRequests {
+			req := &filteredRollerShutterRequests[i]
+			if req == request ||
+				req.Status.Phase != iotv1alpha1.RollerShutterRequestPhaseMoving {
+				continue
+			}
+			// a higher priority request took over the shutter,
+			// so this one will never reach its position.
+			preemptRequest(req, request)
+			updatedRequests = append(updatedRequests, req)
+		}
+	}
+
+	if request != nil {
+		span.SetAttributes(attribute.String("iot.rollershutterrequest.name", request.Name))
+		if err := r.handleRequest(ctx, dev, request, previousStatus.Phase, status); err != nil {
 			return res, fmt.Errorf("reconciling %s: %w", dt, err)
 		}
-	default:
-		meta.SetStatusCondition(&rollerShutter.Status.Conditions, metav1.Condition{
-			Type:    iotv1alpha1.RollerShutterReachable,
-			Status:  metav1.ConditionFalse,
-			Reason:  "UnkownDeviceType",
-			Message: fmt.Sprintf("Unkown device type %q, must be one of: [%s]", dt, shelly25Roller),
-		})
 	}
 
-	// Handle status
+	res, err = r.updateStatus(ctx, rollerShutter, updatedRequests)
+	if err != nil {
+		return res, err
+	}
+	var movements []iotv1alpha1.MovementRecord
+	for _, req := range updatedRequests {
+		previousPhase := previousRequestPhases[req.Name]
+		r.recordRequestEvent(req, previousPhase, status)
+		if meta.IsStatusConditionTrue(
+			req.Status.Conditions, iotv1alpha1.RollerShutterRequestCompleted) {
+			movements = append(movements, newMovementRecord(req, previousPhase, status))
+		}
+	}
+	if err := r.recordMovements(ctx, rollerShutter, movements); err != nil {
+		// requests are already completed, no point in retrying
+		log.Error(err, "recording movement history")
+	}
+	if holdOff > 0 && holdOff < res.RequeueAfter {
+		// check back when the hold-off expires
+		res.RequeueAfter = holdOff
+	}
+	return res, nil
+}
+
+// Maintains a WebSocket for Shelly Gen2 devices, to be notified about changes.
+func (r *RollerShutterReconciler) ensureWebsocket(rollerShutter *iotv1alpha1.RollerShutter) {
+	if r.Websockets == nil {
+		return
+	}
+	if rollerShutter.Spec.DeviceType != shellyPlusCover ||
+		rollerShutter.Spec.Endpoint.MQTT != nil {
+		r.Websockets.Remove(client.ObjectKeyFromObject(rollerShutter))
+		return
+	}
+	r.Websockets.Ensure(rollerShutter, rollerShutter.Spec.Endpoint.URL)
+}
+
+func (r *RollerShutterReconciler) updateStatus(
+	ctx context.Context, rollerShutter *iotv1alpha1.RollerShutter,
+	requests []*iotv1alpha1.RollerShutterRequest,
+) (res ctrl.Result, err error) {
 	rollerShutter.Status.ObservedGeneration = rollerShutter.Generation
 	if err := r.Status().Update(ctx, rollerShutter); err != nil {
 		return res, fmt.Errorf("updating RollerShutter status: %w", err)
 	}
+	reportMetrics(rollerShutter)
 
-	if request != nil {
+	for _, request := range requests {
 		request.Status.ObservedGeneration = request.Generation
 		if err := r.Status().Update(ctx, request); err != nil {
 			return res, fmt.Errorf("updating RollerShutterRequest status: %w", err)
 		}
+		reportRequestMetrics(request)
 	}
 
-	if rollerShutter.Status.Phase == iotv1alpha1.RollerShutterPhaseIdle {
+	if rollerShutter.Status.Phase == iotv1alpha1.RollerShutterPhaseIdle ||
+		len(rollerShutter.Status.Phase) == 0 ||
+		// no need to poll while moving, if the device pushes status changes.
+		r.pushesStatus(rollerShutter) {
 		// always get a new status every now and then
 		res.RequeueAfter = r.DefaultRequeueInterval
 	} else {
@@ -125,97 +425,141 @@ func (r *RollerShutterReconciler) Reconcile(
 	return
 }
 
-func (r *RollerShutterReconciler) reconcileShelly25Roller(
-	ctx context.Context, rollerShutter *iotv1alpha1.RollerShutter,
-	req *iotv1alpha1.RollerShutterRequest,
-) error {
-	c := shelly25rollerclient.NewClient(clients.WithEndpoint(rollerShutter.Spec.Endpoint.URL))
-
-	status, err := c.Status(ctx)
-	if err != nil {
-		return fmt.Errorf("reading status: %w", err)
+// Returns true if status changes of the device are currently pushed,
+// via MQTT or the WebSocket of Gen2 devices.
+func (r *RollerShutterReconciler) pushesStatus(rollerShutter *iotv1alpha1.RollerShutter) bool {
+	key := client.ObjectKeyFromObject(rollerShutter)
+	if rollerShutter.Spec.Endpoint.MQTT != nil {
+		return r.MQTT != nil && r.MQTT.Connected(key)
 	}
+	return rollerShutter.Spec.DeviceType == shellyPlusCover &&
+		r.Websockets != nil && r.Websockets.Connected(key)
+}
 
-	// Status handling
-	meta.SetStatusCondition(&rollerShutter.Status.Conditions, metav1.Condition{
-		Type:    iotv1alpha1.RollerShutterReachable,
-		Status:  metav1.ConditionTrue,
-		Reason:  "Connected",
-		Message: "connected to device",
-	})
-
-	rollerShutter.Status.Position = status.CurrentPos
+func setDeviceStatus(
+	rollerShutter *iotv1alpha1.RollerShutter, status deviceStatus,
+) {
+	rollerShutter.Status.Position = status.Position
 	rollerShutter.Status.Power = int(status.Power)
+	rollerShutter.Status.Phase = status.Phase
+}
 
-	switch status.State {
-	case shelly25rollerclient.StateClose:
-		rollerShutter.Status.Phase = iotv1alpha1.RollerShutterPhaseClosing
-	case shelly25rollerclient.StateOpen:
-		rollerShutter.Status.Phase = iotv1alpha1.RollerShutterPhaseOpening
-	// case shelly25rollerclient.StateStop:
-	default:
-		rollerShutter.Status.Phase = iotv1alpha1.RollerShutterPhaseIdle
+func (r *RollerShutterReconciler) handleRequest(
+	ctx context.Context, dev device,
+	req *iotv1alpha1.RollerShutterRequest,
+	previousPhase iotv1alpha1.RollerShutterPhase,
+	status deviceStatus,
+) (err error) {
+	if req.Status.StartTime == nil {
+		// first time the request is executed
+		now := metav1.Now()
+		startPosition := status.Position
+		req.Status.StartTime = &now
+		req.Status.StartPosition = &startPosition
 	}
 
-	// Request handling
-	if req != nil {
-		if req.Spec.Position != status.CurrentPos {
-			status, err = c.ToPosition(ctx, req.Spec.Position)
-			if err != nil {
-				return fmt.Errorf("commanding to position: %w", err)
-			}
-		}
+	if movementEnded(req, previousPhase, status) {
+		// don't command the shutter again,
+		// it would run into the same obstacle.
+		finishRequest(req, status)
+		return nil
+	}
 
-		if status.State == shelly25rollerclient.StateStop {
-			// Move finished
-			meta.SetStatusCondition(&req.Status.Conditions, metav1.Condition{
-				Type:    iotv1alpha1.RollerShutterRequestCompleted,
-				Status:  metav1.ConditionTrue,
-				Reason:  "AtPosition",
-				Message: "position reached",
-			})
-			req.Status.Phase = iotv1alpha1.RollerShutterRequestPhaseCompleted
-			return nil
-		}
-
-		reason := "Moving"
-		message := "moving shutter to position"
-		if status.State == shelly25rollerclient.StateStop {
-			switch status.StopReason {
-			case shelly25rollerclient.StopReasonObstacle:
-				reason = "Obstacle"
-				message = "obstacle detected, stopped movement"
-			case shelly25rollerclient.StopReasonSafetySwitch:
-				reason = "SafetySwitch"
-				message = "safety switch triggered"
-			case shelly25rollerclient.StopReasonOverpower:
-				reason = "Overpower"
-				message = "overpower detected, stopped movement"
-			}
+	if !atPosition(req, status) {
+		status, err = dev.ToPosition(ctx, req.Spec.Position)
+		if err != nil {
+			return fmt.Errorf("commanding to position: %w", err)
 		}
+	}
 
-		meta.SetStatusCondition(&req.Status.Conditions, metav1.Condition{
-			Type:    iotv1alpha1.RollerShutterRequestCompleted,
-			Status:  metav1.ConditionFalse,
-			Reason:  reason,
-			Message: message,
-		})
-		req.Status.Phase = iotv1alpha1.RollerShutterRequestPhaseMoving
+	if atPosition(req, status) && status.Phase == iotv1alpha1.RollerShutterPhaseIdle {
+		finishRequest(req, status)
+		return nil
 	}
 
+	meta.SetStatusCondition(&req.Status.Conditions, metav1.Condition{
+		Type:    iotv1alpha1.RollerShutterRequestCompleted,
+		Status:  metav1.ConditionFalse,
+		Reason:  "Moving",
+		Message: "moving shutter to position",
+	})
+	req.Status.Phase = iotv1alpha1.RollerShutterRequestPhaseMoving
 	return nil
 }
 
-type sortRequestByCreationTimestamp []iotv1alpha1.RollerShutterRequest
+// Positions within this many percent of the requested position count as reached,
+// as devices round positions and calibration drifts over time.
+const positionTolerance = 2
+
+// Time to wait for the motor to start after commanding a position.
+const motorStartTimeout = 10 * time.Second
+
+func atPosition(req *iotv1alpha1.RollerShutterRequest, status deviceStatus) bool {
+	diff := req.Spec.Position - status.Position
+	return diff >= -positionTolerance && diff <= positionTolerance
+}
+
+// Returns true if the device stopped moving the shutter for the request,
+// either because it was seen moving before or the motor never started.
+func movementEnded(
+	req *iotv1alpha1.RollerShutterRequest,
+	previousPhase iotv1alpha1.RollerShutterPhase,
+	status deviceStatus,
+) bool {
+	if req.Status.Phase != iotv1alpha1.RollerShutterRequestPhaseMoving ||
+		status.Phase != iotv1alpha1.RollerShutterPhaseIdle {
+		return false
+	}
+	wasMoving := previousPhase == iotv1alpha1.RollerShutterPhaseOpening ||
+		previousPhase == iotv1alpha1.RollerShutterPhaseClosing
+	return wasMoving || time.Since(req.Status.StartTime.Time) > motorStartTimeout
+}
 
-func (p sortRequestByCreationTimestamp) Len() int {
+// Completes the request after the movement ended,
+// reporting why the shutter stopped.
+func finishRequest(req *iotv1alpha1.RollerShutterRequest, status deviceStatus) {
+	condition := metav1.Condition{
+		Type:    iotv1alpha1.RollerShutterRequestCompleted,
+		Status:  metav1.ConditionTrue,
+		Reason:  "AtPosition",
+		Message: "position reached",
+	}
+	if stop, ok := abnormalStops[status.StopReason]; ok && !atPosition(req, status) {
+		condition.Reason = stop.reason
+		condition.Message = fmt.Sprintf("%s at position %d", stop.message, status.Position)
+	} else if !atPosition(req, status) {
+		condition.Reason = "Stopped"
+		condition.Message = fmt.Sprintf("stopped at position %d", status.Position)
+	}
+	meta.SetStatusCondition(&req.Status.Conditions, condition)
+	req.Status.Phase = iotv1alpha1.RollerShutterRequestPhaseCompleted
+}
+
+// Marks the request as completed, because another request took over the shutter.
+func preemptRequest(req, by *iotv1alpha1.RollerShutterRequest) {
+	meta.SetStatusCondition(&req.Status.Conditions, metav1.Condition{
+		Type:    iotv1alpha1.RollerShutterRequestCompleted,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Preempted",
+		Message: fmt.Sprintf("preempted by RollerShutterRequest %s", by.Name),
+	})
+	req.Status.Phase = iotv1alpha1.RollerShutterRequestPhaseCompleted
+}
+
+// Sorts requests by priority and then by creation timestamp.
+type sortRequestsByPriority []iotv1alpha1.RollerShutterRequest
+
+func (p sortRequestsByPriority) Len() int {
 	return len(p)
 }
 
-func (p sortRequestByCreationTimestamp) Less(i, j int) bool {
+func (p sortRequestsByPriority) Less(i, j int) bool {
+	if p[i].Spec.Priority != p[j].Spec.Priority {
+		return p[i].Spec.Priority > p[j].Spec.Priority
+	}
 	return p[i].GetCreationTimestamp().UTC().Before(p[j].GetCreationTimestamp().UTC())
 }
 
-func (p sortRequestByCreationTimestamp) Swap(i, j int) {
+func (p sortRequestsByPriority) Swap(i, j int) {
 	p[i], p[j] = p[j], p[i]
 }
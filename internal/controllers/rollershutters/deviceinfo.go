@@ -0,0 +1,195 @@
+package rollershutters
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+	"github.com/thetechnick/iot-operator/internal/identity"
+)
+
+// WiFi signal strength in dBm below which the signal is considered weak.
+const weakSignalThreshold = -80
+
+// Implemented by devices reporting health information.
+type healthReader interface {
+	Health(ctx context.Context) (deviceHealth, error)
+}
+
+type deviceHealth struct {
+	Uptime time.Duration
+	// WiFi signal strength in dBm.
+	RSSI int
+	// Internal temperature in degrees Celsius, nil if not reported.
+	Temperature *float64
+	// nil if not reported.
+	OverTemperature *bool
+	// Firmware version available for update, empty if up-to-date.
+	AvailableFirmware string
+	// false if the device can't tell whether updates are available.
+	ReportsUpdates bool
+}
+
+// Reports device info and health conditions,
+// if supported by the device.
+func reportDeviceInfo(
+	ctx context.Context, rollerShutter *iotv1alpha1.RollerShutter,
+	dev device, id identity.Identity,
+) error {
+	hr, ok := dev.(healthReader)
+	if !ok {
+		return nil
+	}
+	health, err := hr.Health(ctx)
+	if err != nil {
+		return fmt.Errorf("reading health: %w", err)
+	}
+
+	info := &iotv1alpha1.DeviceInfo{
+		Model:             id.Model,
+		Firmware:          id.Firmware,
+		AvailableFirmware: health.AvailableFirmware,
+		WiFiRSSI:          health.RSSI,
+	}
+	bootTime := metav1.NewTime(time.Now().Add(-health.Uptime).Truncate(time.Second))
+	if previous := rollerShutter.Status.DeviceInfo; previous != nil && previous.BootTime != nil &&
+		math.Abs(float64(bootTime.Sub(previous.BootTime.Time))) < float64(time.Minute) {
+		// uptime and request latency don't add up exactly
+		bootTime = *previous.BootTime
+	}
+	info.BootTime = &bootTime
+	if health.Temperature != nil {
+		info.Temperature = resource.NewMilliQuantity(
+			int64(math.Round(*health.Temperature*1000)), resource.DecimalSI)
+	}
+	rollerShutter.Status.DeviceInfo = info
+
+	// Conditions
+	if health.OverTemperature != nil {
+		if *health.OverTemperature {
+			meta.SetStatusCondition(&rollerShutter.Status.Conditions, metav1.Condition{
+				Type:    iotv1alpha1.RollerShutterOverTemperature,
+				Status:  metav1.ConditionTrue,
+				Reason:  "OverTemperature",
+				Message: "device reports overheating",
+			})
+		} else {
+			meta.SetStatusCondition(&rollerShutter.Status.Conditions, metav1.Condition{
+				Type:    iotv1alpha1.RollerShutterOverTemperature,
+				Status:  metav1.ConditionFalse,
+				Reason:  "NormalTemperature",
+				Message: "device temperature is normal",
+			})
+		}
+	}
+
+	if health.RSSI < weakSignalThreshold {
+		meta.SetStatusCondition(&rollerShutter.Status.Conditions, metav1.Condition{
+			Type:   iotv1alpha1.RollerShutterWeakSignal,
+			Status: metav1.ConditionTrue,
+			Reason: "WeakSignal",
+			Message: fmt.Sprintf("WiFi signal is below %d dBm",
+				weakSignalThreshold),
+		})
+	} else {
+		meta.SetStatusCondition(&rollerShutter.Status.Conditions, metav1.Condition{
+			Type:    iotv1alpha1.RollerShutterWeakSignal,
+			Status:  metav1.ConditionFalse,
+			Reason:  "GoodSignal",
+			Message: "WiFi signal is good",
+		})
+	}
+
+	if health.ReportsUpdates {
+		if len(health.AvailableFirmware) > 0 {
+			meta.SetStatusCondition(&rollerShutter.Status.Conditions, metav1.Condition{
+				Type:    iotv1alpha1.RollerShutterUpdateAvailable,
+				Status:  metav1.ConditionTrue,
+				Reason:  "UpdateAvailable",
+				Message: fmt.Sprintf("firmware %s is available", health.AvailableFirmware),
+			})
+		} else {
+			meta.SetStatusCondition(&rollerShutter.Status.Conditions, metav1.Condition{
+				Type:    iotv1alpha1.RollerShutterUpdateAvailable,
+				Status:  metav1.ConditionFalse,
+				Reason:  "UpToDate",
+				Message: "firmware is up-to-date",
+			})
+		}
+	}
+	return nil
+}
+
+// Returns true if the boot time reported by the device changed.
+func rebooted(previous, current *iotv1alpha1.RollerShutterStatus) bool {
+	if previous.DeviceInfo == nil || previous.DeviceInfo.BootTime == nil ||
+		current.DeviceInfo == nil || current.DeviceInfo.BootTime == nil {
+		return false
+	}
+	return !previous.DeviceInfo.BootTime.Equal(current.DeviceInfo.BootTime)
+}
+
+func (d *shelly25RollerDevice) Health(ctx context.Context) (deviceHealth, error) {
+	status, err := d.c.DeviceStatus(ctx)
+	if err != nil {
+		return deviceHealth{}, err
+	}
+
+	h := deviceHealth{
+		Uptime:          time.Duration(status.Uptime) * time.Second,
+		RSSI:            status.WiFiSta.RSSI,
+		Temperature:     &status.Temperature,
+		OverTemperature: &status.OverTemperature,
+		ReportsUpdates:  true,
+	}
+	if status.Update.HasUpdate {
+		h.AvailableFirmware = status.Update.NewVersion
+	}
+	return h, nil
+}
+
+func (d *shellyPlusCoverDevice) Health(ctx context.Context) (deviceHealth, error) {
+	status, err := d.c.ShellyGetStatus(ctx)
+	if err != nil {
+		return deviceHealth{}, err
+	}
+
+	h := deviceHealth{
+		Uptime:         time.Duration(status.Sys.Uptime) * time.Second,
+		RSSI:           status.WiFi.RSSI,
+		ReportsUpdates: true,
+	}
+	if stable := status.Sys.AvailableUpdates.Stable; stable != nil {
+		h.AvailableFirmware = stable.Version
+	}
+	if cover := status.Cover0; cover != nil {
+		h.Temperature = cover.Temperature.TC
+		overTemperature := false
+		for _, e := range cover.Errors {
+			if e == "overtemp" {
+				overTemperature = true
+			}
+		}
+		h.OverTemperature = &overTemperature
+	}
+	return h, nil
+}
+
+func (d *tasmotaShutterDevice) Health(ctx context.Context) (deviceHealth, error) {
+	status, err := d.c.Status(ctx)
+	if err != nil {
+		return deviceHealth{}, err
+	}
+
+	// Tasmota neither reports temperature nor available updates.
+	return deviceHealth{
+		Uptime: time.Duration(status.StatusSTS.UptimeSec) * time.Second,
+		RSSI:   status.StatusSTS.Wifi.Signal,
+	}, nil
+}
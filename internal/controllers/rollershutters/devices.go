@@ -0,0 +1,309 @@
+package rollershutters
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+	"github.com/thetechnick/iot-operator/internal/clients"
+	"github.com/thetechnick/iot-operator/internal/clients/mqttclient"
+	"github.com/thetechnick/iot-operator/internal/clients/shelly25rollerclient"
+	"github.com/thetechnick/iot-operator/internal/clients/shellyrpcclient"
+	"github.com/thetechnick/iot-operator/internal/clients/tasmotaclient"
+)
+
+const (
+	shelly25Roller  = "Shelly25Roller"
+	shellyPlusCover = "ShellyPlusCover"
+	tasmotaShutter  = "TasmotaShutter"
+	genericHTTP     = "GenericHTTP"
+)
+
+// Device independent interface to control a roller shutter.
+type device interface {
+	Status(ctx context.Context) (deviceStatus, error)
+	ToPosition(ctx context.Context, position int) (deviceStatus, error)
+	Stop(ctx context.Context) (deviceStatus, error)
+}
+
+// Device independent roller shutter status.
+type deviceStatus struct {
+	// Position in percentage open.
+	Position int
+	// Power consumption in Watts.
+	Power float64
+	Phase iotv1alpha1.RollerShutterPhase
+	// Why the last movement was stopped.
+	StopReason stopReason
+}
+
+type stopReason string
+
+const (
+	stopReasonNormal       stopReason = "Normal"
+	stopReasonSafetySwitch stopReason = "SafetySwitch"
+	stopReasonObstacle     stopReason = "Obstacle"
+	stopReasonOverpower    stopReason = "Overpower"
+)
+
+// Returns the device implementation for the given RollerShutter
+// or nil, if the device type is unknown.
+// Secrets are read via the uncached apiReader,
+// to not cache all Secrets of the cluster.
+func newDevice(
+	ctx context.Context, c, apiReader client.Reader,
+	mqttManager *mqttclient.Manager,
+	rollerShutter *iotv1alpha1.RollerShutter,
+) (device, error) {
+	if rollerShutter.Spec.Endpoint.MQTT != nil {
+		return newMQTTDevice(ctx, apiReader, mqttManager, rollerShutter)
+	}
+
+	endpoint := clients.WithEndpoint(rollerShutter.Spec.Endpoint.URL)
+	switch rollerShutter.Spec.DeviceType {
+	case shelly25Roller:
+		return &shelly25RollerDevice{
+			c: shelly25rollerclient.NewClient(endpoint),
+		}, nil
+	case shellyPlusCover:
+		return &shellyPlusCoverDevice{
+			c: shellyrpcclient.NewClient(endpoint),
+		}, nil
+	case tasmotaShutter:
+		return &tasmotaShutterDevice{
+			c: tasmotaclient.NewClient(endpoint),
+		}, nil
+	case genericHTTP:
+		profile, err := genericHTTPProfile(ctx, c, rollerShutter)
+		if err != nil {
+			return nil, err
+		}
+		return newGenericHTTPDevice(endpoint, profile), nil
+	}
+	return nil, nil
+}
+
+// List of all supported device types for error reporting.
+var knownDeviceTypes = []string{
+	shelly25Roller,
+	shellyPlusCover,
+	tasmotaShutter,
+	genericHTTP,
+}
+
+// Returns the phase of a shutter that was just commanded to the given position.
+func commandedPhase(status deviceStatus, position int) deviceStatus {
+	switch {
+	case position > status.Position:
+		status.Phase = iotv1alpha1.RollerShutterPhaseOpening
+	case position < status.Position:
+		status.Phase = iotv1alpha1.RollerShutterPhaseClosing
+	}
+	return status
+}
+
+type shelly25RollerDevice struct {
+	c *shelly25rollerclient.Client
+}
+
+func (d *shelly25RollerDevice) Status(ctx context.Context) (deviceStatus, error) {
+	status, err := d.c.Status(ctx)
+	if err != nil {
+		return deviceStatus{}, err
+	}
+	return d.convertStatus(status), nil
+}
+
+func (d *shelly25RollerDevice) ToPosition(
+	ctx context.Context, position int,
+) (deviceStatus, error) {
+	status, err := d.c.ToPosition(ctx, position)
+	if err != nil {
+		return deviceStatus{}, err
+	}
+	return d.convertStatus(status), nil
+}
+
+func (d *shelly25RollerDevice) Stop(ctx context.Context) (deviceStatus, error) {
+	status, err := d.c.Stop(ctx)
+	if err != nil {
+		return deviceStatus{}, err
+	}
+	return d.convertStatus(status), nil
+}
+
+func (d *shelly25RollerDevice) convertStatus(
+	status shelly25rollerclient.Status,
+) deviceStatus {
+	s := deviceStatus{
+		Position: status.CurrentPos,
+		Power:    status.Power,
+	}
+
+	switch status.State {
+	case shelly25rollerclient.StateClose:
+		s.Phase = iotv1alpha1.RollerShutterPhaseClosing
+	case shelly25rollerclient.StateOpen:
+		s.Phase = iotv1alpha1.RollerShutterPhaseOpening
+	// case shelly25rollerclient.StateStop:
+	default:
+		s.Phase = iotv1alpha1.RollerShutterPhaseIdle
+	}
+
+	switch status.StopReason {
+	case shelly25rollerclient.StopReasonObstacle:
+		s.StopReason = stopReasonObstacle
+	case shelly25rollerclient.StopReasonSafetySwitch:
+		s.StopReason = stopReasonSafetySwitch
+	case shelly25rollerclient.StopReasonOverpower:
+		s.StopReason = stopReasonOverpower
+	default:
+		s.StopReason = stopReasonNormal
+	}
+	return s
+}
+
+// Shelly Gen2 covers, e.g. Shelly Plus 2PM in cover mode.
+type shellyPlusCoverDevice struct {
+	c *shellyrpcclient.Client
+}
+
+// Gen2 devices support multiple covers, we always control the first.
+const shellyPlusCoverID = 0
+
+func (d *shellyPlusCoverDevice) Status(ctx context.Context) (deviceStatus, error) {
+	status, err := d.c.CoverGetStatus(ctx, shellyPlusCoverID)
+	if err != nil {
+		return deviceStatus{}, err
+	}
+	if status.CurrentPos == nil {
+		return deviceStatus{}, fmt.Errorf("cover is not calibrated")
+	}
+
+	s := deviceStatus{
+		Position: *status.CurrentPos,
+		Power:    status.APower,
+		// Gen2 covers report obstacles and safety switches only via events.
+		StopReason: stopReasonNormal,
+	}
+	switch status.State {
+	case shellyrpcclient.CoverStateOpening:
+		s.Phase = iotv1alpha1.RollerShutterPhaseOpening
+	case shellyrpcclient.CoverStateClosing:
+		s.Phase = iotv1alpha1.RollerShutterPhaseClosing
+	default:
+		s.Phase = iotv1alpha1.RollerShutterPhaseIdle
+	}
+	return s, nil
+}
+
+func (d *shellyPlusCoverDevice) ToPosition(
+	ctx context.Context, position int,
+) (deviceStatus, error) {
+	if err := d.c.CoverGoToPosition(ctx, shellyPlusCoverID, position); err != nil {
+		return deviceStatus{}, err
+	}
+	status, err := d.Status(ctx)
+	if err != nil {
+		return deviceStatus{}, err
+	}
+	// the motor might not have started yet
+	return commandedPhase(status, position), nil
+}
+
+func (d *shellyPlusCoverDevice) Stop(ctx context.Context) (deviceStatus, error) {
+	if err := d.c.CoverStop(ctx, shellyPlusCoverID); err != nil {
+		return deviceStatus{}, err
+	}
+	return d.Status(ctx)
+}
+
+// Shutters on devices running the Tasmota firmware.
+type tasmotaShutterDevice struct {
+	c *tasmotaclient.Client
+}
+
+// Tasmota supports multiple shutters per device, we always control the first.
+const tasmotaShutterIndex = 1
+
+func (d *tasmotaShutterDevice) Status(ctx context.Context) (deviceStatus, error) {
+	// without a shutter configured, Tasmota ignores shutter commands
+	// and relays might be switched on their own.
+	if _, err := d.c.ShutterConfig(ctx, tasmotaShutterIndex); err != nil {
+		return deviceStatus{}, err
+	}
+	status, err := d.c.ShutterStatus(ctx, tasmotaShutterIndex)
+	if err != nil {
+		return deviceStatus{}, err
+	}
+	return d.convertStatus(status), nil
+}
+
+func (d *tasmotaShutterDevice) ToPosition(
+	ctx context.Context, position int,
+) (deviceStatus, error) {
+	status, err := d.c.ShutterPosition(ctx, tasmotaShutterIndex, position)
+	if err != nil {
+		return deviceStatus{}, err
+	}
+	return d.convertStatus(status), nil
+}
+
+func (d *tasmotaShutterDevice) Stop(ctx context.Context) (deviceStatus, error) {
+	status, err := d.c.ShutterStop(ctx, tasmotaShutterIndex)
+	if err != nil {
+		return deviceStatus{}, err
+	}
+	return d.convertStatus(status), nil
+}
+
+func (d *tasmotaShutterDevice) convertStatus(
+	status tasmotaclient.ShutterStatus,
+) deviceStatus {
+	s := deviceStatus{
+		Position: status.Position,
+		// Tasmota does not report why a movement stopped.
+		StopReason: stopReasonNormal,
+	}
+
+	switch status.Direction {
+	case tasmotaclient.DirectionOpening:
+		s.Phase = iotv1alpha1.RollerShutterPhaseOpening
+	case tasmotaclient.DirectionClosing:
+		s.Phase = iotv1alpha1.RollerShutterPhaseClosing
+	default:
+		s.Phase = iotv1alpha1.RollerShutterPhaseIdle
+	}
+	return s
+}
+
+// Returns the GenericHTTP profile, either inline or from the referenced DeviceProfile.
+func genericHTTPProfile(
+	ctx context.Context, c client.Reader,
+	rollerShutter *iotv1alpha1.RollerShutter,
+) (*iotv1alpha1.RollerShutterHTTPProfile, error) {
+	genericHTTP := rollerShutter.Spec.GenericHTTP
+	switch {
+	case genericHTTP == nil:
+		return nil, fmt.Errorf("genericHTTP must be set for device type %s", rollerShutter.Spec.DeviceType)
+	case genericHTTP.Profile != nil:
+		return genericHTTP.Profile, nil
+	case genericHTTP.ProfileRef == nil:
+		return nil, fmt.Errorf("either genericHTTP.profile or genericHTTP.profileRef must be set")
+	}
+
+	deviceProfile := &iotv1alpha1.DeviceProfile{}
+	if err := c.Get(ctx, client.ObjectKey{
+		Name:      genericHTTP.ProfileRef.Name,
+		Namespace: rollerShutter.Namespace,
+	}, deviceProfile); err != nil {
+		return nil, fmt.Errorf("getting DeviceProfile: %w", err)
+	}
+	if deviceProfile.Spec.RollerShutter == nil {
+		return nil, fmt.Errorf(
+			"DeviceProfile %s has no rollerShutter profile", deviceProfile.Name)
+	}
+	return deviceProfile.Spec.RollerShutter, nil
+}
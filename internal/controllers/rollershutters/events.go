@@ -0,0 +1,92 @@
+package rollershutters
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+)
+
+// Event reasons and messages for movements stopped by the device.
+var abnormalStops = map[stopReason]struct{ reason, message string }{
+	stopReasonObstacle:     {"Obstacle", "obstacle detected, stopped movement"},
+	stopReasonSafetySwitch: {"SafetySwitch", "safety switch triggered"},
+	stopReasonOverpower:    {"Overpower", "overpower detected, stopped movement"},
+}
+
+// Sets the Reachable condition to False and records a Warning Event,
+// unless the same problem was already reported.
+func (r *RollerShutterReconciler) setUnreachable(
+	rollerShutter *iotv1alpha1.RollerShutter, reason, message string,
+) {
+	if c := meta.FindStatusCondition(
+		rollerShutter.Status.Conditions, iotv1alpha1.RollerShutterReachable,
+	); c == nil || c.Status != metav1.ConditionFalse || c.Reason != reason {
+		r.Recorder.Event(rollerShutter, corev1.EventTypeWarning, reason, message)
+	}
+	meta.SetStatusCondition(&rollerShutter.Status.Conditions, metav1.Condition{
+		Type:    iotv1alpha1.RollerShutterReachable,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+// Records a Warning Event when the device stopped a movement.
+func (r *RollerShutterReconciler) recordStop(
+	rollerShutter *iotv1alpha1.RollerShutter,
+	previousStatus *iotv1alpha1.RollerShutterStatus,
+	status deviceStatus,
+) {
+	if previousStatus.Phase == iotv1alpha1.RollerShutterPhaseIdle ||
+		len(previousStatus.Phase) == 0 ||
+		status.Phase != iotv1alpha1.RollerShutterPhaseIdle {
+		return
+	}
+	if stop, ok := abnormalStops[status.StopReason]; ok {
+		r.Recorder.Eventf(rollerShutter, corev1.EventTypeWarning, stop.reason,
+			"%s at position %d", stop.message, status.Position)
+	}
+}
+
+// Records Events for phase changes of a RollerShutterRequest.
+func (r *RollerShutterReconciler) recordRequestEvent(
+	req *iotv1alpha1.RollerShutterRequest,
+	previousPhase iotv1alpha1.RollerShutterRequestPhase,
+	status deviceStatus,
+) {
+	if req.Status.Phase == previousPhase {
+		return
+	}
+
+	switch req.Status.Phase {
+	case iotv1alpha1.RollerShutterRequestPhaseMoving:
+		r.Recorder.Eventf(req, corev1.EventTypeNormal, "MovementStarted",
+			"moving shutter to position %d", req.Spec.Position)
+
+	case iotv1alpha1.RollerShutterRequestPhaseCompleted:
+		c := meta.FindStatusCondition(
+			req.Status.Conditions, iotv1alpha1.RollerShutterRequestCompleted)
+		switch {
+		case c == nil:
+			return
+		case c.Reason == "AtPosition":
+			r.Recorder.Eventf(req, corev1.EventTypeNormal, "Completed",
+				"position %d reached", status.Position)
+		case c.Reason == "Preempted":
+			r.Recorder.Event(req, corev1.EventTypeNormal, c.Reason, c.Message)
+		default:
+			// stopped by the device before reaching the position
+			r.Recorder.Event(req, corev1.EventTypeWarning, c.Reason, c.Message)
+		}
+
+	case iotv1alpha1.RollerShutterRequestPhaseRejected:
+		c := meta.FindStatusCondition(
+			req.Status.Conditions, iotv1alpha1.RollerShutterRequestCompleted)
+		if c == nil {
+			return
+		}
+		r.Recorder.Event(req, corev1.EventTypeWarning, c.Reason, c.Message)
+	}
+}
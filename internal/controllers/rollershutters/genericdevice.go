@@ -0,0 +1,108 @@
+package rollershutters
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+	"github.com/thetechnick/iot-operator/internal/clients"
+	"github.com/thetechnick/iot-operator/internal/clients/httpjsonclient"
+	"github.com/thetechnick/iot-operator/internal/clients/httptemplateclient"
+)
+
+// Roller shutters driven by HTTP request templates from a device profile.
+type genericHTTPDevice struct {
+	c       *httptemplateclient.Client
+	profile *iotv1alpha1.RollerShutterHTTPProfile
+}
+
+func newGenericHTTPDevice(
+	endpoint clients.WithEndpoint,
+	profile *iotv1alpha1.RollerShutterHTTPProfile,
+) *genericHTTPDevice {
+	return &genericHTTPDevice{
+		c:       httptemplateclient.NewClient(endpoint),
+		profile: profile,
+	}
+}
+
+// Data available to request templates.
+type genericHTTPTemplateData struct {
+	Position int
+}
+
+func (d *genericHTTPDevice) Status(ctx context.Context) (deviceStatus, error) {
+	var doc interface{}
+	if err := d.c.Execute(
+		ctx, request(d.profile.Status), genericHTTPTemplateData{}, &doc,
+	); err != nil {
+		return deviceStatus{}, fmt.Errorf("status request: %w", err)
+	}
+
+	position, err := httpjsonclient.Extract(doc, d.profile.PositionJSONPath)
+	if err != nil {
+		return deviceStatus{}, fmt.Errorf("reading position: %w", err)
+	}
+	positionFloat, err := strconv.ParseFloat(position, 64)
+	if err != nil {
+		return deviceStatus{}, fmt.Errorf("parsing position %q: %w", position, err)
+	}
+
+	state, err := httpjsonclient.Extract(doc, d.profile.StateJSONPath)
+	if err != nil {
+		return deviceStatus{}, fmt.Errorf("reading state: %w", err)
+	}
+
+	s := deviceStatus{
+		Position:   int(positionFloat),
+		StopReason: stopReasonNormal,
+	}
+	switch state {
+	case d.profile.OpeningState:
+		s.Phase = iotv1alpha1.RollerShutterPhaseOpening
+	case d.profile.ClosingState:
+		s.Phase = iotv1alpha1.RollerShutterPhaseClosing
+	default:
+		s.Phase = iotv1alpha1.RollerShutterPhaseIdle
+	}
+	return s, nil
+}
+
+func (d *genericHTTPDevice) ToPosition(
+	ctx context.Context, position int,
+) (deviceStatus, error) {
+	if err := d.c.Execute(
+		ctx, request(d.profile.Move),
+		genericHTTPTemplateData{Position: position}, nil,
+	); err != nil {
+		return deviceStatus{}, fmt.Errorf("move request: %w", err)
+	}
+	// move responses are not standardized, so read the status separately.
+	status, err := d.Status(ctx)
+	if err != nil {
+		return deviceStatus{}, err
+	}
+	// the motor might not have started yet
+	return commandedPhase(status, position), nil
+}
+
+func (d *genericHTTPDevice) Stop(ctx context.Context) (deviceStatus, error) {
+	if d.profile.Stop == nil {
+		return deviceStatus{}, fmt.Errorf("device profile has no stop request")
+	}
+	if err := d.c.Execute(
+		ctx, request(*d.profile.Stop), genericHTTPTemplateData{}, nil,
+	); err != nil {
+		return deviceStatus{}, fmt.Errorf("stop request: %w", err)
+	}
+	return d.Status(ctx)
+}
+
+func request(t iotv1alpha1.HTTPRequestTemplate) httptemplateclient.Request {
+	return httptemplateclient.Request{
+		Method: t.Method,
+		Path:   t.Path,
+		Body:   t.Body,
+	}
+}
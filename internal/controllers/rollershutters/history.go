@@ -0,0 +1,130 @@
+package rollershutters
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+)
+
+// Entries kept in newly created MovementHistories.
+const defaultMovementHistoryLimit = 50
+
+// Returns the MovementHistory entry for a finished request.
+func newMovementRecord(
+	req *iotv1alpha1.RollerShutterRequest,
+	previousPhase iotv1alpha1.RollerShutterRequestPhase,
+	status deviceStatus,
+) iotv1alpha1.MovementRecord {
+	record := iotv1alpha1.MovementRecord{
+		Request:        req.Name,
+		RequestedBy:    requestedBy(req),
+		Automated:      req.Spec.Automated,
+		FromPosition:   req.Status.StartPosition,
+		TargetPosition: req.Spec.Position,
+		ToPosition:     status.Position,
+		StartTime:      req.CreationTimestamp,
+		Outcome:        req.Status.Phase,
+	}
+	if req.Status.StartTime != nil {
+		record.StartTime = *req.Status.StartTime
+	}
+	if c := meta.FindStatusCondition(
+		req.Status.Conditions, iotv1alpha1.RollerShutterRequestCompleted); c != nil {
+		record.Reason = c.Reason
+		record.Duration = metav1.Duration{
+			Duration: c.LastTransitionTime.Sub(record.StartTime.Time),
+		}
+	}
+	// stop reasons are kept by the device until the next movement
+	if stop, ok := abnormalStops[status.StopReason]; ok &&
+		previousPhase == iotv1alpha1.RollerShutterRequestPhaseMoving {
+		record.StopReason = stop.reason
+	}
+	return record
+}
+
+// Returns the controller of the request as Kind/Name,
+// or the field manager that created it.
+// Managed fields are not ordered and include status updates
+// by the operator itself, so the earliest writer of the spec is used.
+func requestedBy(req *iotv1alpha1.RollerShutterRequest) string {
+	if owner := metav1.GetControllerOf(req); owner != nil {
+		return owner.Kind + "/" + owner.Name
+	}
+	var creator *metav1.ManagedFieldsEntry
+	for i := range req.ManagedFields {
+		entry := &req.ManagedFields[i]
+		if len(entry.Subresource) > 0 || entry.Time == nil {
+			continue
+		}
+		if creator == nil || entry.Time.Before(creator.Time) {
+			creator = entry
+		}
+	}
+	if creator == nil {
+		return ""
+	}
+	return creator.Manager
+}
+
+// Prepends the records to the MovementHistory of the RollerShutter,
+// creating the MovementHistory if needed.
+func (r *RollerShutterReconciler) recordMovements(
+	ctx context.Context, rollerShutter *iotv1alpha1.RollerShutter,
+	records []iotv1alpha1.MovementRecord,
+) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		history := &iotv1alpha1.MovementHistory{}
+		err := r.Get(ctx, client.ObjectKeyFromObject(rollerShutter), history)
+		if errors.IsNotFound(err) {
+			history = &iotv1alpha1.MovementHistory{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      rollerShutter.Name,
+					Namespace: rollerShutter.Namespace,
+				},
+				Spec: iotv1alpha1.MovementHistorySpec{
+					RollerShutter: corev1.LocalObjectReference{Name: rollerShutter.Name},
+					Limit:         defaultMovementHistoryLimit,
+				},
+			}
+			if err := controllerutil.SetControllerReference(
+				rollerShutter, history, r.Scheme); err != nil {
+				return fmt.Errorf("setting controller reference: %w", err)
+			}
+			if err := r.Create(ctx, history); err != nil {
+				return fmt.Errorf("creating MovementHistory: %w", err)
+			}
+		} else if err != nil {
+			return fmt.Errorf("getting MovementHistory: %w", err)
+		}
+
+		entries := make([]iotv1alpha1.MovementRecord, 0, len(records)+len(history.Status.Entries))
+		for i := len(records) - 1; i >= 0; i-- {
+			entries = append(entries, records[i])
+		}
+		entries = append(entries, history.Status.Entries...)
+
+		limit := history.Spec.Limit
+		if limit <= 0 {
+			limit = defaultMovementHistoryLimit
+		}
+		if len(entries) > limit {
+			entries = entries[:limit]
+		}
+		history.Status.Entries = entries
+		return r.Status().Update(ctx, history)
+	})
+}
@@ -0,0 +1,109 @@
+package rollershutters
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+	"github.com/thetechnick/iot-operator/internal/identity"
+)
+
+// Device types reporting their identity via HTTP.
+var identifiableDeviceTypes = map[string]struct{}{
+	shelly25Roller:  {},
+	shellyPlusCover: {},
+	tasmotaShutter:  {},
+}
+
+// Identities verified per RollerShutter,
+// to not query the device on every reconcile.
+type identityCache struct {
+	mux     sync.Mutex
+	entries map[client.ObjectKey]verifiedIdentity
+}
+
+type verifiedIdentity struct {
+	url string
+	id  identity.Identity
+}
+
+func (c *identityCache) get(key client.ObjectKey) (verifiedIdentity, bool) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	v, ok := c.entries[key]
+	return v, ok
+}
+
+func (c *identityCache) set(key client.ObjectKey, v verifiedIdentity) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if c.entries == nil {
+		c.entries = map[client.ObjectKey]verifiedIdentity{}
+	}
+	c.entries[key] = v
+}
+
+// Forces verification on the next reconcile.
+func (c *identityCache) forget(key client.ObjectKey) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	delete(c.entries, key)
+}
+
+// Verifies that the device answering at the endpoint URL
+// is the one recorded on first contact.
+// Returns false if a different device answered.
+// The identity is only queried on first contact, when the endpoint changed
+// or when the device was unreachable, as it may have been replaced since.
+func (r *RollerShutterReconciler) verifyIdentity(
+	ctx context.Context, rollerShutter *iotv1alpha1.RollerShutter,
+) (id identity.Identity, verified bool, err error) {
+	endpoint := rollerShutter.Spec.Endpoint
+	if _, ok := identifiableDeviceTypes[rollerShutter.Spec.DeviceType]; !ok ||
+		endpoint.MQTT != nil {
+		return id, true, nil
+	}
+
+	key := client.ObjectKeyFromObject(rollerShutter)
+	if cached, ok := r.identities.get(key); ok && cached.url == endpoint.URL &&
+		meta.IsStatusConditionTrue(
+			rollerShutter.Status.Conditions, iotv1alpha1.RollerShutterReachable) {
+		return cached.id, true, nil
+	}
+	r.identities.forget(key)
+
+	id, err = identity.Query(ctx, endpoint.URL)
+	if err != nil {
+		return id, false, fmt.Errorf("querying identity: %w", err)
+	}
+
+	if len(rollerShutter.Status.MAC) == 0 {
+		// first contact
+		rollerShutter.Status.MAC = id.MAC
+	}
+	if rollerShutter.Status.MAC != id.MAC {
+		meta.SetStatusCondition(&rollerShutter.Status.Conditions, metav1.Condition{
+			Type:   iotv1alpha1.RollerShutterIdentityMismatch,
+			Status: metav1.ConditionTrue,
+			Reason: "IdentityMismatch",
+			Message: fmt.Sprintf(
+				"expected device with MAC %s at %s, found %s, refusing to send commands",
+				rollerShutter.Status.MAC, endpoint.URL, id.MAC),
+		})
+		return id, false, nil
+	}
+
+	meta.SetStatusCondition(&rollerShutter.Status.Conditions, metav1.Condition{
+		Type:    iotv1alpha1.RollerShutterIdentityMismatch,
+		Status:  metav1.ConditionFalse,
+		Reason:  "IdentityVerified",
+		Message: fmt.Sprintf("device with MAC %s answered", id.MAC),
+	})
+	r.identities.set(key, verifiedIdentity{url: endpoint.URL, id: id})
+	return id, true, nil
+}
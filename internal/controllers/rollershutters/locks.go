@@ -0,0 +1,313 @@
+package rollershutters
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+	"github.com/thetechnick/iot-operator/internal/sensors"
+)
+
+// lock prevents RollerShutterRequests from being executed.
+type lock struct {
+	// Reason reported on the Locked condition and on blocked requests.
+	Reason  string
+	Message string
+	// Reject blocked requests instead of holding them back.
+	Reject bool
+	// Returns true if the request may be executed despite the lock.
+	Exempt func(req *iotv1alpha1.RollerShutterRequest) bool
+}
+
+// Returns the first lock blocking the given request.
+// Requests of ProtectionPolicies are never blocked,
+// as they protect the shutter from damage.
+func blockingLock(
+	locks []lock, req *iotv1alpha1.RollerShutterRequest,
+) (lock, bool) {
+	if isProtectionRequest(req) {
+		return lock{}, false
+	}
+	for _, l := range locks {
+		if l.Exempt != nil && l.Exempt(req) {
+			continue
+		}
+		return l, true
+	}
+	return lock{}, false
+}
+
+// Collects all locks that are currently placed on the RollerShutter.
+func (r *RollerShutterReconciler) locks(
+	ctx context.Context, rollerShutter *iotv1alpha1.RollerShutter,
+) ([]lock, error) {
+	locks, err := r.protectionPolicyLocks(ctx, rollerShutter)
+	if err != nil {
+		return nil, err
+	}
+
+	updateLocks, err := r.firmwareUpdateLocks(ctx, rollerShutter)
+	if err != nil {
+		return nil, err
+	}
+	locks = append(locks, updateLocks...)
+
+	if l, ok := r.frostProtectionLock(ctx, rollerShutter); ok {
+		locks = append(locks, l)
+	}
+	if l, ok := r.windowContactLock(ctx, rollerShutter); ok {
+		locks = append(locks, l)
+	}
+	return locks, nil
+}
+
+// Lock placed while a door or window is open.
+func (r *RollerShutterReconciler) windowContactLock(
+	ctx context.Context, rollerShutter *iotv1alpha1.RollerShutter,
+) (lock, bool) {
+	windowContact := rollerShutter.Spec.WindowContact
+	if windowContact == nil {
+		return lock{}, false
+	}
+
+	minPosition := windowContact.MinPosition
+	l := lock{
+		Reason: "WindowOpen",
+		Reject: windowContact.RequestPolicy == iotv1alpha1.ProtectionRequestPolicyReject,
+		Exempt: func(req *iotv1alpha1.RollerShutterRequest) bool {
+			return req.Spec.Position >= minPosition
+		},
+	}
+
+	reading, err := sensors.Read(
+		ctx, r.Client, rollerShutter.Namespace, windowContact.Source)
+	if err != nil {
+		// assume the worst, so nobody is locked out.
+		l.Reject = false
+		l.Message = fmt.Sprintf("window contact unknown: %v", err)
+		return l, true
+	}
+	open, err := sensors.ParseBool(reading)
+	if err != nil {
+		l.Reject = false
+		l.Message = fmt.Sprintf("window contact unknown: %v", err)
+		return l, true
+	}
+
+	if !open {
+		return lock{}, false
+	}
+	l.Message = fmt.Sprintf("window open, closing below position %d is blocked", minPosition)
+	return l, true
+}
+
+// Lock placed while the temperature is below the frost protection threshold.
+func (r *RollerShutterReconciler) frostProtectionLock(
+	ctx context.Context, rollerShutter *iotv1alpha1.RollerShutter,
+) (lock, bool) {
+	frostProtection := rollerShutter.Spec.FrostProtection
+	if frostProtection == nil {
+		return lock{}, false
+	}
+
+	reading, err := r.temperatures.read(
+		ctx, r.Client, rollerShutter.Namespace, frostProtection.Source)
+	if err != nil {
+		// we can't tell whether it's safe to move,
+		// so hold requests until the sensor is back.
+		return lock{
+			Reason:  "FrostProtection",
+			Message: fmt.Sprintf("temperature unknown: %v", err),
+		}, true
+	}
+	temperature, err := sensors.ParseQuantity(reading)
+	if err != nil {
+		return lock{
+			Reason:  "FrostProtection",
+			Message: fmt.Sprintf("temperature unknown: %v", err),
+		}, true
+	}
+
+	if temperature.Cmp(frostProtection.Threshold) >= 0 {
+		return lock{}, false
+	}
+	return lock{
+		Reason: "FrostProtection",
+		Message: fmt.Sprintf("temperature below frost protection threshold of %s",
+			frostProtection.Threshold.String()),
+		Reject: true,
+	}, true
+}
+
+// Temperatures change slowly, so readings from URLs are reused for this long,
+// instead of calling the sensor on every reconcile.
+const temperatureCacheTTL = time.Minute
+
+// Caches readings of SensorSources with a URL.
+// Sensor objects are read from the informer cache and not cached again.
+type readingCache struct {
+	mux     sync.Mutex
+	entries map[iotv1alpha1.SensorSource]cachedReading
+}
+
+type cachedReading struct {
+	value string
+	err   error
+	time  time.Time
+}
+
+// Reads the SensorSource via sensors.Read,
+// returning the cached reading or error while it is younger than the TTL.
+func (c *readingCache) read(
+	ctx context.Context, reader client.Reader,
+	namespace string, source iotv1alpha1.SensorSource,
+) (string, error) {
+	if source.SensorRef != nil {
+		return sensors.Read(ctx, reader, namespace, source)
+	}
+
+	c.mux.Lock()
+	cached, ok := c.entries[source]
+	c.mux.Unlock()
+	if ok && time.Since(cached.time) < temperatureCacheTTL {
+		return cached.value, cached.err
+	}
+
+	// read without holding the lock,
+	// to not block other RollerShutters while the sensor is slow.
+	value, err := sensors.Read(ctx, reader, namespace, source)
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if c.entries == nil {
+		c.entries = map[iotv1alpha1.SensorSource]cachedReading{}
+	}
+	c.entries[source] = cachedReading{value: value, err: err, time: time.Now()}
+	return value, err
+}
+
+// Locks placed by active ProtectionPolicies.
+func (r *RollerShutterReconciler) protectionPolicyLocks(
+	ctx context.Context, rollerShutter *iotv1alpha1.RollerShutter,
+) ([]lock, error) {
+	policyList := &iotv1alpha1.ProtectionPolicyList{}
+	if err := r.List(ctx, policyList, client.InNamespace(rollerShutter.Namespace)); err != nil {
+		return nil, fmt.Errorf("listing ProtectionPolicies in namespace %s: %w", rollerShutter.Namespace, err)
+	}
+
+	var locks []lock
+	for i := range policyList.Items {
+		policy := &policyList.Items[i]
+		if !meta.IsStatusConditionTrue(
+			policy.Status.Conditions, iotv1alpha1.ProtectionPolicyActive) {
+			continue
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.RollerShutterSelector)
+		if err != nil {
+			// invalid selectors are reported by the ProtectionPolicy controller
+			continue
+		}
+		if !selector.Matches(labels.Set(rollerShutter.Labels)) {
+			continue
+		}
+
+		locks = append(locks, lock{
+			Reason:  "ProtectionPolicy",
+			Message: fmt.Sprintf("locked by ProtectionPolicy %s", policy.Name),
+			Reject:  policy.Spec.RequestPolicy == iotv1alpha1.ProtectionRequestPolicyReject,
+		})
+	}
+	return locks, nil
+}
+
+// Returns true if the request was created by a ProtectionPolicy.
+func isProtectionRequest(req *iotv1alpha1.RollerShutterRequest) bool {
+	owner := metav1.GetControllerOf(req)
+	return owner != nil && owner.Kind == "ProtectionPolicy"
+}
+
+// Locks placed while a FirmwareUpdatePolicy is updating the device.
+func (r *RollerShutterReconciler) firmwareUpdateLocks(
+	ctx context.Context, rollerShutter *iotv1alpha1.RollerShutter,
+) ([]lock, error) {
+	policyList := &iotv1alpha1.FirmwareUpdatePolicyList{}
+	if err := r.List(ctx, policyList, client.InNamespace(rollerShutter.Namespace)); err != nil {
+		return nil, fmt.Errorf("listing FirmwareUpdatePolicies in namespace %s: %w", rollerShutter.Namespace, err)
+	}
+
+	var locks []lock
+	for _, policy := range policyList.Items {
+		for _, d := range policy.Status.Devices {
+			if d.Name != rollerShutter.Name ||
+				d.Phase != iotv1alpha1.FirmwareUpdatePhaseUpdating {
+				continue
+			}
+			// the device is flashing or rebooting,
+			// hold requests until the update is done.
+			locks = append(locks, lock{
+				Reason:  "FirmwareUpdate",
+				Message: fmt.Sprintf("firmware update by FirmwareUpdatePolicy %s in progress", policy.Name),
+			})
+		}
+	}
+	return locks, nil
+}
+
+// Reports the Locked condition.
+func reportLocks(
+	rollerShutter *iotv1alpha1.RollerShutter, locks []lock,
+) {
+	if len(locks) == 0 {
+		meta.SetStatusCondition(&rollerShutter.Status.Conditions, metav1.Condition{
+			Type:    iotv1alpha1.RollerShutterLocked,
+			Status:  metav1.ConditionFalse,
+			Reason:  "Unlocked",
+			Message: "no locks",
+		})
+		return
+	}
+
+	messages := make([]string, len(locks))
+	for i, l := range locks {
+		messages[i] = l.Message
+	}
+	meta.SetStatusCondition(&rollerShutter.Status.Conditions, metav1.Condition{
+		Type:    iotv1alpha1.RollerShutterLocked,
+		Status:  metav1.ConditionTrue,
+		Reason:  locks[0].Reason,
+		Message: strings.Join(messages, ", "),
+	})
+}
+
+// Marks the request as held back or rejected by the given lock.
+func lockRequest(
+	req *iotv1alpha1.RollerShutterRequest, l lock,
+) {
+	if l.Reject {
+		meta.SetStatusCondition(&req.Status.Conditions, metav1.Condition{
+			Type:    iotv1alpha1.RollerShutterRequestCompleted,
+			Status:  metav1.ConditionTrue,
+			Reason:  l.Reason,
+			Message: fmt.Sprintf("rejected: %s", l.Message),
+		})
+		req.Status.Phase = iotv1alpha1.RollerShutterRequestPhaseRejected
+		return
+	}
+
+	meta.SetStatusCondition(&req.Status.Conditions, metav1.Condition{
+		Type:    iotv1alpha1.RollerShutterRequestCompleted,
+		Status:  metav1.ConditionFalse,
+		Reason:  l.Reason,
+		Message: fmt.Sprintf("waiting: %s", l.Message),
+	})
+	req.Status.Phase = iotv1alpha1.RollerShutterRequestPhasePending
+}
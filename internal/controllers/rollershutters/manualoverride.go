@@ -0,0 +1,87 @@
+package rollershutters
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+)
+
+// Records a manual override, when the shutter moved since the last
+// reconcile without a RollerShutterRequest commanding the move.
+func detectManualOverride(
+	rollerShutter *iotv1alpha1.RollerShutter,
+	previousStatus *iotv1alpha1.RollerShutterStatus,
+	requests []iotv1alpha1.RollerShutterRequest,
+) {
+	if !meta.IsStatusConditionTrue(
+		previousStatus.Conditions, iotv1alpha1.RollerShutterReachable) {
+		// nothing to compare against
+		return
+	}
+
+	for _, req := range requests {
+		if req.Status.Phase == iotv1alpha1.RollerShutterRequestPhaseMoving {
+			// movement is explained by this request
+			return
+		}
+	}
+
+	if previousStatus.Position == rollerShutter.Status.Position &&
+		rollerShutter.Status.Phase == iotv1alpha1.RollerShutterPhaseIdle {
+		return
+	}
+
+	now := metav1.Now()
+	rollerShutter.Status.LastManualOverrideTime = &now
+}
+
+// Reports the ManualOverride condition and returns the remaining hold-off.
+func reportManualOverride(
+	rollerShutter *iotv1alpha1.RollerShutter,
+) (holdOff time.Duration) {
+	var holdOffUntil time.Time
+	if lastOverride := rollerShutter.Status.LastManualOverrideTime; lastOverride != nil {
+		holdOffUntil = lastOverride.Add(rollerShutter.Spec.ManualOverrideHoldOff.Duration)
+		holdOff = time.Until(holdOffUntil)
+	}
+
+	if holdOff <= 0 {
+		meta.SetStatusCondition(&rollerShutter.Status.Conditions, metav1.Condition{
+			Type:    iotv1alpha1.RollerShutterManualOverride,
+			Status:  metav1.ConditionFalse,
+			Reason:  "NoOverride",
+			Message: "no recent manual override",
+		})
+		return 0
+	}
+
+	meta.SetStatusCondition(&rollerShutter.Status.Conditions, metav1.Condition{
+		Type:   iotv1alpha1.RollerShutterManualOverride,
+		Status: metav1.ConditionTrue,
+		Reason: "ManualOverride",
+		Message: fmt.Sprintf(
+			"shutter moved manually, holding back automated requests until %s",
+			holdOffUntil.UTC().Format(time.RFC3339)),
+	})
+	return holdOff
+}
+
+// Marks the request as held back by a manual override.
+func holdManualOverride(
+	req *iotv1alpha1.RollerShutterRequest,
+	rollerShutter *iotv1alpha1.RollerShutter,
+) {
+	meta.SetStatusCondition(&req.Status.Conditions, metav1.Condition{
+		Type:   iotv1alpha1.RollerShutterRequestCompleted,
+		Status: metav1.ConditionFalse,
+		Reason: "ManualOverride",
+		Message: fmt.Sprintf(
+			"RollerShutter %s was moved manually, waiting for hold-off to expire",
+			rollerShutter.Name),
+	})
+	req.Status.Phase = iotv1alpha1.RollerShutterRequestPhasePending
+}
@@ -0,0 +1,123 @@
+package rollershutters
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+)
+
+var (
+	positionGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iot_rollershutter_position_percent",
+			Help: "Position of the shutter in percentage open.",
+		},
+		[]string{"namespace", "name"},
+	)
+	powerGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iot_rollershutter_power_watts",
+			Help: "Power consumption of the shutter motor.",
+		},
+		[]string{"namespace", "name"},
+	)
+	reachableGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iot_rollershutter_reachable",
+			Help: "Whether the device can be contacted.",
+		},
+		[]string{"namespace", "name"},
+	)
+	phaseGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iot_rollershutter_phase",
+			Help: "Current phase of the shutter, 1 for the active phase.",
+		},
+		[]string{"namespace", "name", "phase"},
+	)
+	requestsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iot_rollershutter_requests_total",
+			Help: "Completed RollerShutterRequests by outcome and reason.",
+		},
+		[]string{"namespace", "outcome", "reason"},
+	)
+	requestDurationHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "iot_rollershutter_request_duration_seconds",
+			Help: "Time from creation to completion of RollerShutterRequests.",
+			Buckets: []float64{
+				1, 5, 10, 20, 30, 60, 120, 300, 600, 1800, 3600,
+			},
+		},
+		[]string{"namespace", "outcome"},
+	)
+)
+
+// All phases exported via phaseGauge.
+var phases = []iotv1alpha1.RollerShutterPhase{
+	iotv1alpha1.RollerShutterPhaseIdle,
+	iotv1alpha1.RollerShutterPhaseOpening,
+	iotv1alpha1.RollerShutterPhaseClosing,
+}
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		positionGauge,
+		powerGauge,
+		reachableGauge,
+		phaseGauge,
+		requestsCounter,
+		requestDurationHistogram,
+	)
+}
+
+// Exports the status of the RollerShutter as metrics.
+func reportMetrics(rollerShutter *iotv1alpha1.RollerShutter) {
+	ns, name := rollerShutter.Namespace, rollerShutter.Name
+	positionGauge.WithLabelValues(ns, name).Set(float64(rollerShutter.Status.Position))
+	powerGauge.WithLabelValues(ns, name).Set(float64(rollerShutter.Status.Power))
+
+	var reachable float64
+	if meta.IsStatusConditionTrue(
+		rollerShutter.Status.Conditions, iotv1alpha1.RollerShutterReachable) {
+		reachable = 1
+	}
+	reachableGauge.WithLabelValues(ns, name).Set(reachable)
+
+	for _, phase := range phases {
+		var active float64
+		if rollerShutter.Status.Phase == phase {
+			active = 1
+		}
+		phaseGauge.WithLabelValues(ns, name, string(phase)).Set(active)
+	}
+}
+
+// Removes all metrics of a deleted RollerShutter.
+func deleteMetrics(key client.ObjectKey) {
+	positionGauge.DeleteLabelValues(key.Namespace, key.Name)
+	powerGauge.DeleteLabelValues(key.Namespace, key.Name)
+	reachableGauge.DeleteLabelValues(key.Namespace, key.Name)
+	for _, phase := range phases {
+		phaseGauge.DeleteLabelValues(key.Namespace, key.Name, string(phase))
+	}
+}
+
+// Records the outcome of a completed RollerShutterRequest.
+func reportRequestMetrics(req *iotv1alpha1.RollerShutterRequest) {
+	completed := meta.FindStatusCondition(
+		req.Status.Conditions, iotv1alpha1.RollerShutterRequestCompleted)
+	if completed == nil || completed.Status != metav1.ConditionTrue {
+		return
+	}
+
+	outcome := string(req.Status.Phase)
+	requestsCounter.WithLabelValues(req.Namespace, outcome, completed.Reason).Inc()
+	requestDurationHistogram.WithLabelValues(req.Namespace, outcome).Observe(
+		completed.LastTransitionTime.Sub(req.CreationTimestamp.Time).Seconds())
+}
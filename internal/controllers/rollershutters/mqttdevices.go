@@ -0,0 +1,251 @@
+package rollershutters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+	"github.com/thetechnick/iot-operator/internal/clients/mqttclient"
+	"github.com/thetechnick/iot-operator/internal/clients/shelly25rollerclient"
+	"github.com/thetechnick/iot-operator/internal/clients/tasmotaclient"
+)
+
+// Device receiving status updates via MQTT.
+type mqttDevice interface {
+	device
+	// Topic filter to receive status updates on.
+	statusFilter() string
+}
+
+// Error reaching the broker, in contrast to configuration errors.
+type mqttConnectionError struct {
+	err error
+}
+
+func (e *mqttConnectionError) Error() string {
+	return e.err.Error()
+}
+
+func (e *mqttConnectionError) Unwrap() error {
+	return e.err
+}
+
+// Returns true if the error is temporary and should be retried.
+func isMQTTConnectionError(err error) bool {
+	var connErr *mqttConnectionError
+	return errors.As(err, &connErr)
+}
+
+// Constructors of devices supporting MQTT, by device type.
+var mqttDeviceTypes = map[string]func(
+	conn *mqttclient.Connection, topic string) mqttDevice{
+	shelly25Roller: func(conn *mqttclient.Connection, topic string) mqttDevice {
+		return &shelly25RollerMQTTDevice{conn: conn, topic: topic}
+	},
+	tasmotaShutter: func(conn *mqttclient.Connection, topic string) mqttDevice {
+		return &tasmotaShutterMQTTDevice{conn: conn, topic: topic}
+	},
+}
+
+// Returns the MQTT device implementation for the given RollerShutter
+// and subscribes the RollerShutter to status updates of the device.
+func newMQTTDevice(
+	ctx context.Context, c client.Reader,
+	mqttManager *mqttclient.Manager,
+	rollerShutter *iotv1alpha1.RollerShutter,
+) (device, error) {
+	if mqttManager == nil {
+		return nil, fmt.Errorf("MQTT transport is not enabled")
+	}
+	newDev, ok := mqttDeviceTypes[rollerShutter.Spec.DeviceType]
+	if !ok {
+		return nil, fmt.Errorf(
+			"device type %s does not support MQTT", rollerShutter.Spec.DeviceType)
+	}
+
+	endpoint := rollerShutter.Spec.Endpoint.MQTT
+	broker, err := mqttBroker(ctx, c, rollerShutter.Namespace, endpoint.BrokerSecret.Name)
+	if err != nil {
+		return nil, &mqttConnectionError{err: err}
+	}
+	conn, err := mqttManager.Connection(broker)
+	if err != nil {
+		return nil, &mqttConnectionError{err: err}
+	}
+
+	dev := newDev(conn, endpoint.Topic)
+	if err := conn.Subscribe(dev.statusFilter(), rollerShutter); err != nil {
+		return nil, &mqttConnectionError{err: err}
+	}
+	// drop subscriptions on the previous broker, e.g. after a Secret rotation
+	mqttManager.Release(client.ObjectKeyFromObject(rollerShutter), conn)
+	return dev, nil
+}
+
+// Loads the broker connection details from the given Secret.
+func mqttBroker(
+	ctx context.Context, c client.Reader, namespace, name string,
+) (mqttclient.Broker, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{
+		Name:      name,
+		Namespace: namespace,
+	}, secret); err != nil {
+		return mqttclient.Broker{}, fmt.Errorf("getting MQTT broker Secret: %w", err)
+	}
+
+	broker := mqttclient.Broker{
+		URL:      string(secret.Data["url"]),
+		Username: string(secret.Data["username"]),
+		Password: string(secret.Data["password"]),
+	}
+	if len(broker.URL) == 0 {
+		return mqttclient.Broker{}, fmt.Errorf("MQTT broker Secret %s has no url key", name)
+	}
+	return broker, nil
+}
+
+// Shelly 2.5 in roller mode, using the Gen1 MQTT API.
+// Topic templates, relative to the device topic:
+// - status: <topic>/roller/0, <topic>/roller/0/pos, /power and /stop_reason
+// - commands: <topic>/roller/0/command and <topic>/roller/0/command/pos
+type shelly25RollerMQTTDevice struct {
+	conn  *mqttclient.Connection
+	topic string
+}
+
+func (d *shelly25RollerMQTTDevice) statusFilter() string {
+	return d.topic + "/roller/0/#"
+}
+
+func (d *shelly25RollerMQTTDevice) requestStatus() error {
+	return d.conn.Publish(d.topic+"/command", "update")
+}
+
+func (d *shelly25RollerMQTTDevice) Status(ctx context.Context) (deviceStatus, error) {
+	state, ok := d.conn.Message(d.topic + "/roller/0")
+	if !ok {
+		return deviceStatus{}, d.noStatus()
+	}
+	pos, ok := d.conn.Message(d.topic + "/roller/0/pos")
+	if !ok {
+		return deviceStatus{}, d.noStatus()
+	}
+
+	position, err := strconv.Atoi(string(pos))
+	if err != nil {
+		return deviceStatus{}, fmt.Errorf("parsing position %q: %w", pos, err)
+	}
+	status := shelly25rollerclient.Status{
+		State:      shelly25rollerclient.State(state),
+		CurrentPos: position,
+	}
+	if power, ok := d.conn.Message(d.topic + "/roller/0/power"); ok {
+		status.Power, _ = strconv.ParseFloat(string(power), 64)
+	}
+	if stopReason, ok := d.conn.Message(d.topic + "/roller/0/stop_reason"); ok {
+		status.StopReason = shelly25rollerclient.StopReason(stopReason)
+	}
+	return (&shelly25RollerDevice{}).convertStatus(status), nil
+}
+
+func (d *shelly25RollerMQTTDevice) noStatus() error {
+	if err := d.requestStatus(); err != nil {
+		return err
+	}
+	return fmt.Errorf("waiting for status via MQTT")
+}
+
+func (d *shelly25RollerMQTTDevice) ToPosition(
+	ctx context.Context, position int,
+) (deviceStatus, error) {
+	status, err := d.Status(ctx)
+	if err != nil {
+		return deviceStatus{}, err
+	}
+	if err := d.conn.Publish(
+		d.topic+"/roller/0/command/pos", strconv.Itoa(position)); err != nil {
+		return deviceStatus{}, err
+	}
+	return commandedPhase(status, position), nil
+}
+
+func (d *shelly25RollerMQTTDevice) Stop(ctx context.Context) (deviceStatus, error) {
+	if err := d.conn.Publish(d.topic+"/roller/0/command", "stop"); err != nil {
+		return deviceStatus{}, err
+	}
+	return d.Status(ctx)
+}
+
+// Tasmota shutters, using the MQTT command API.
+// Topic templates, relative to the device topic:
+// - status: stat/<topic>/RESULT
+// - commands: cmnd/<topic>/ShutterPosition1 and cmnd/<topic>/ShutterStop1
+type tasmotaShutterMQTTDevice struct {
+	conn  *mqttclient.Connection
+	topic string
+}
+
+func (d *tasmotaShutterMQTTDevice) statusFilter() string {
+	return "stat/" + d.topic + "/RESULT"
+}
+
+func (d *tasmotaShutterMQTTDevice) requestStatus() error {
+	// without payload, the command reports the current position.
+	return d.conn.Publish(
+		"cmnd/"+d.topic+"/ShutterPosition"+strconv.Itoa(tasmotaShutterIndex), "")
+}
+
+func (d *tasmotaShutterMQTTDevice) Status(ctx context.Context) (deviceStatus, error) {
+	// RESULT messages also carry responses to other commands,
+	// so the last message might not contain the shutter status.
+	msg, ok := d.conn.Message(d.statusFilter())
+	if !ok {
+		return deviceStatus{}, d.noStatus()
+	}
+	res := map[string]tasmotaclient.ShutterStatus{}
+	if err := json.Unmarshal(msg, &res); err != nil {
+		return deviceStatus{}, d.noStatus()
+	}
+	status, ok := res["Shutter"+strconv.Itoa(tasmotaShutterIndex)]
+	if !ok {
+		return deviceStatus{}, d.noStatus()
+	}
+	return (&tasmotaShutterDevice{}).convertStatus(status), nil
+}
+
+func (d *tasmotaShutterMQTTDevice) noStatus() error {
+	if err := d.requestStatus(); err != nil {
+		return err
+	}
+	return fmt.Errorf("waiting for status via MQTT")
+}
+
+func (d *tasmotaShutterMQTTDevice) ToPosition(
+	ctx context.Context, position int,
+) (deviceStatus, error) {
+	status, err := d.Status(ctx)
+	if err != nil {
+		return deviceStatus{}, err
+	}
+	if err := d.conn.Publish(
+		"cmnd/"+d.topic+"/ShutterPosition"+strconv.Itoa(tasmotaShutterIndex),
+		strconv.Itoa(position)); err != nil {
+		return deviceStatus{}, err
+	}
+	return commandedPhase(status, position), nil
+}
+
+func (d *tasmotaShutterMQTTDevice) Stop(ctx context.Context) (deviceStatus, error) {
+	if err := d.conn.Publish(
+		"cmnd/"+d.topic+"/ShutterStop"+strconv.Itoa(tasmotaShutterIndex), ""); err != nil {
+		return deviceStatus{}, err
+	}
+	return d.Status(ctx)
+}
@@ -0,0 +1,276 @@
+package rollershutters
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+	"github.com/thetechnick/iot-operator/internal/clients/shelly25rollerclient"
+)
+
+// Implemented by devices with configurable settings.
+type configurableDevice interface {
+	// Returns the settings configured on the device.
+	Settings(ctx context.Context) (iotv1alpha1.RollerShutterSettings, error)
+	// Applies all non-empty settings to the device.
+	ApplySettings(ctx context.Context, settings iotv1alpha1.RollerShutterSettings) error
+}
+
+// Compares the desired settings against the device configuration
+// and applies them, unless in dry-run mode.
+func reconcileSettings(
+	ctx context.Context, rollerShutter *iotv1alpha1.RollerShutter, dev device,
+) error {
+	desired := rollerShutter.Spec.Settings
+	if desired == nil {
+		meta.RemoveStatusCondition(
+			&rollerShutter.Status.Conditions, iotv1alpha1.RollerShutterConfigInSync)
+		return nil
+	}
+
+	cd, ok := dev.(configurableDevice)
+	if !ok {
+		meta.SetStatusCondition(&rollerShutter.Status.Conditions, metav1.Condition{
+			Type:   iotv1alpha1.RollerShutterConfigInSync,
+			Status: metav1.ConditionUnknown,
+			Reason: "Unsupported",
+			Message: fmt.Sprintf("device type %q does not support settings",
+				rollerShutter.Spec.DeviceType),
+		})
+		return nil
+	}
+
+	actual, err := cd.Settings(ctx)
+	if err != nil {
+		return fmt.Errorf("reading settings: %w", err)
+	}
+	changes, drift := settingsDrift(*desired, actual)
+	if len(drift) == 0 {
+		meta.SetStatusCondition(&rollerShutter.Status.Conditions, metav1.Condition{
+			Type:    iotv1alpha1.RollerShutterConfigInSync,
+			Status:  metav1.ConditionTrue,
+			Reason:  "InSync",
+			Message: "device configuration matches settings",
+		})
+		return nil
+	}
+
+	if desired.DryRun {
+		meta.SetStatusCondition(&rollerShutter.Status.Conditions, metav1.Condition{
+			Type:    iotv1alpha1.RollerShutterConfigInSync,
+			Status:  metav1.ConditionFalse,
+			Reason:  "Drifted",
+			Message: "dry-run, not applying: " + strings.Join(drift, "; "),
+		})
+		return nil
+	}
+
+	if c := meta.FindStatusCondition(
+		rollerShutter.Status.Conditions, iotv1alpha1.RollerShutterConfigInSync,
+	); c != nil && c.Reason == "NotAccepted" && c.ObservedGeneration == rollerShutter.Generation {
+		// the device did not accept the settings before,
+		// don't rewrite its flash on every poll until the settings change.
+		return nil
+	}
+
+	if err := cd.ApplySettings(ctx, changes); err != nil {
+		meta.SetStatusCondition(&rollerShutter.Status.Conditions, metav1.Condition{
+			Type:    iotv1alpha1.RollerShutterConfigInSync,
+			Status:  metav1.ConditionFalse,
+			Reason:  "ApplyFailed",
+			Message: err.Error(),
+		})
+		return nil
+	}
+
+	// devices may clamp or ignore values
+	actual, err = cd.Settings(ctx)
+	if err != nil {
+		return fmt.Errorf("reading settings: %w", err)
+	}
+	if _, remaining := settingsDrift(*desired, actual); len(remaining) > 0 {
+		meta.SetStatusCondition(&rollerShutter.Status.Conditions, metav1.Condition{
+			Type:               iotv1alpha1.RollerShutterConfigInSync,
+			Status:             metav1.ConditionFalse,
+			Reason:             "NotAccepted",
+			Message:            "device did not accept: " + strings.Join(remaining, "; "),
+			ObservedGeneration: rollerShutter.Generation,
+		})
+		return nil
+	}
+	meta.SetStatusCondition(&rollerShutter.Status.Conditions, metav1.Condition{
+		Type:    iotv1alpha1.RollerShutterConfigInSync,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Applied",
+		Message: "corrected: " + strings.Join(drift, "; "),
+	})
+	return nil
+}
+
+// Returns the desired settings differing from the actual settings
+// and a description of each difference.
+func settingsDrift(
+	desired, actual iotv1alpha1.RollerShutterSettings,
+) (changes iotv1alpha1.RollerShutterSettings, drift []string) {
+	report := func(field string, actual, desired interface{}) {
+		drift = append(drift, fmt.Sprintf(
+			"%s is %v on device, want %v", field, actual, desired))
+	}
+
+	if d, a := desired.MaxTravelTime, actual.MaxTravelTime; d != nil &&
+		(a == nil || a.Duration != d.Duration) {
+		changes.MaxTravelTime = d
+		report("maxTravelTime", durationString(a), d.Duration)
+	}
+	if d, a := desired.DefaultState, actual.DefaultState; len(d) > 0 && d != a {
+		changes.DefaultState = d
+		report("defaultState", a, d)
+	}
+	if d, a := desired.InputMode, actual.InputMode; len(d) > 0 && d != a {
+		changes.InputMode = d
+		report("inputMode", a, d)
+	}
+
+	desiredObstacle := desired.ObstacleDetection
+	if desiredObstacle == nil {
+		return
+	}
+	actualObstacle := actual.ObstacleDetection
+	if actualObstacle == nil {
+		actualObstacle = &iotv1alpha1.RollerShutterObstacleDetection{}
+	}
+	obstacleChanges := iotv1alpha1.RollerShutterObstacleDetection{}
+	var obstacleChanged bool
+	if d, a := desiredObstacle.Mode, actualObstacle.Mode; len(d) > 0 && d != a {
+		obstacleChanges.Mode, obstacleChanged = d, true
+		report("obstacleDetection.mode", a, d)
+	}
+	if d, a := desiredObstacle.Action, actualObstacle.Action; len(d) > 0 && d != a {
+		obstacleChanges.Action, obstacleChanged = d, true
+		report("obstacleDetection.action", a, d)
+	}
+	if d, a := desiredObstacle.Power, actualObstacle.Power; d != nil &&
+		(a == nil || *a != *d) {
+		obstacleChanges.Power, obstacleChanged = d, true
+		var actualPower interface{} = "unset"
+		if a != nil {
+			actualPower = *a
+		}
+		report("obstacleDetection.power", actualPower, *d)
+	}
+	// the device only supports whole seconds, see ApplySettings.
+	if d, a := desiredObstacle.Delay, actualObstacle.Delay; d != nil &&
+		(a == nil || a.Duration != d.Duration.Round(time.Second)) {
+		obstacleChanges.Delay, obstacleChanged = d, true
+		report("obstacleDetection.delay", durationString(a), d.Duration)
+	}
+	if obstacleChanged {
+		changes.ObstacleDetection = &obstacleChanges
+	}
+	return
+}
+
+func durationString(d *metav1.Duration) string {
+	if d == nil {
+		return "unset"
+	}
+	return d.Duration.String()
+}
+
+// Shelly settings values, indexed by API value.
+var (
+	shellyDefaultStates = map[iotv1alpha1.RollerShutterDefaultState]string{
+		iotv1alpha1.RollerShutterDefaultStateStop:   "stop",
+		iotv1alpha1.RollerShutterDefaultStateOpen:   "open",
+		iotv1alpha1.RollerShutterDefaultStateClose:  "close",
+		iotv1alpha1.RollerShutterDefaultStateSwitch: "switch",
+	}
+	shellyInputModes = map[iotv1alpha1.RollerShutterInputMode]string{
+		iotv1alpha1.RollerShutterInputModeOpenClose: "openclose",
+		iotv1alpha1.RollerShutterInputModeOneButton: "onebutton",
+	}
+	shellyObstacleModes = map[iotv1alpha1.RollerShutterObstacleMode]string{
+		iotv1alpha1.RollerShutterObstacleModeDisabled:     "disabled",
+		iotv1alpha1.RollerShutterObstacleModeWhileOpening: "while_opening",
+		iotv1alpha1.RollerShutterObstacleModeWhileClosing: "while_closing",
+		iotv1alpha1.RollerShutterObstacleModeWhileMoving:  "while_moving",
+	}
+	shellyObstacleActions = map[iotv1alpha1.RollerShutterObstacleAction]string{
+		iotv1alpha1.RollerShutterObstacleActionStop:    "stop",
+		iotv1alpha1.RollerShutterObstacleActionReverse: "reverse",
+	}
+)
+
+func (d *shelly25RollerDevice) Settings(
+	ctx context.Context,
+) (iotv1alpha1.RollerShutterSettings, error) {
+	s, err := d.c.RollerSettings(ctx)
+	if err != nil {
+		return iotv1alpha1.RollerShutterSettings{}, err
+	}
+
+	maxTime := time.Duration(math.Round(s.MaxTime*1000)) * time.Millisecond
+	obstaclePower := s.ObstaclePower
+	settings := iotv1alpha1.RollerShutterSettings{
+		MaxTravelTime: &metav1.Duration{Duration: maxTime},
+		ObstacleDetection: &iotv1alpha1.RollerShutterObstacleDetection{
+			Power: &obstaclePower,
+			Delay: &metav1.Duration{
+				Duration: time.Duration(s.ObstacleDelay) * time.Second,
+			},
+		},
+	}
+	for state, v := range shellyDefaultStates {
+		if v == s.DefaultState {
+			settings.DefaultState = state
+		}
+	}
+	for mode, v := range shellyInputModes {
+		if v == s.InputMode {
+			settings.InputMode = mode
+		}
+	}
+	for mode, v := range shellyObstacleModes {
+		if v == s.ObstacleMode {
+			settings.ObstacleDetection.Mode = mode
+		}
+	}
+	for action, v := range shellyObstacleActions {
+		if v == s.ObstacleAction {
+			settings.ObstacleDetection.Action = action
+		}
+	}
+	return settings, nil
+}
+
+func (d *shelly25RollerDevice) ApplySettings(
+	ctx context.Context, settings iotv1alpha1.RollerShutterSettings,
+) error {
+	params := shelly25rollerclient.RollerSettingsParams{
+		DefaultState: shellyDefaultStates[settings.DefaultState],
+		InputMode:    shellyInputModes[settings.InputMode],
+	}
+	if settings.MaxTravelTime != nil {
+		maxTime := settings.MaxTravelTime.Seconds()
+		params.MaxTime = &maxTime
+	}
+	if o := settings.ObstacleDetection; o != nil {
+		params.ObstacleMode = shellyObstacleModes[o.Mode]
+		params.ObstacleAction = shellyObstacleActions[o.Action]
+		params.ObstaclePower = o.Power
+		if o.Delay != nil {
+			// device only supports full seconds
+			delay := int(math.Round(o.Delay.Seconds()))
+			params.ObstacleDelay = &delay
+		}
+	}
+
+	_, err := d.c.SetRollerSettings(ctx, params)
+	return err
+}
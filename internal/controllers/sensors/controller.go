@@ -0,0 +1,108 @@
+package sensors
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+)
+
+type SensorReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+func (r *SensorReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(
+			&iotv1alpha1.Sensor{},
+			// readings are written to the status on every poll,
+			// reacting to them would cut the PollInterval short.
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Complete(r)
+}
+
+func (r *SensorReconciler) Reconcile(
+	ctx context.Context, req ctrl.Request) (res ctrl.Result, err error) {
+	log := r.Log.WithValues("sensor", req.NamespacedName.String())
+	defer log.Info("reconciled")
+
+	sensor := &iotv1alpha1.Sensor{}
+	if err := r.Get(ctx, req.NamespacedName, sensor); err != nil {
+		return res, client.IgnoreNotFound(err)
+	}
+
+	// Determine Client
+	dt := sensor.Spec.DeviceType
+	dev, err := newDevice(sensor)
+	if err != nil {
+		return res, fmt.Errorf("creating %s client: %w", dt, err)
+	}
+	if dev == nil {
+		meta.SetStatusCondition(&sensor.Status.Conditions, metav1.Condition{
+			Type:   iotv1alpha1.SensorReachable,
+			Status: metav1.ConditionFalse,
+			Reason: "UnkownDeviceType",
+			Message: fmt.Sprintf("Unkown device type %q, must be one of: [%s]",
+				dt, strings.Join(knownDeviceTypes, ", ")),
+		})
+		return r.updateStatus(ctx, sensor)
+	}
+
+	// Status handling
+	readings, err := dev.Read(ctx)
+	if err != nil {
+		// Battery powered devices are asleep most of the time,
+		// so we keep the last readings around and report their age.
+		meta.SetStatusCondition(&sensor.Status.Conditions, metav1.Condition{
+			Type:    iotv1alpha1.SensorReachable,
+			Status:  metav1.ConditionFalse,
+			Reason:  "ReadError",
+			Message: err.Error(),
+		})
+		return r.updateStatus(ctx, sensor)
+	}
+	meta.SetStatusCondition(&sensor.Status.Conditions, metav1.Condition{
+		Type:    iotv1alpha1.SensorReachable,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Connected",
+		Message: "connected to device",
+	})
+
+	now := metav1.Now()
+	sensor.Status.Readings = make([]iotv1alpha1.SensorReading, len(readings))
+	for i, rd := range readings {
+		sensor.Status.Readings[i] = iotv1alpha1.SensorReading{
+			Name:  rd.Name,
+			Type:  rd.Type,
+			Value: rd.Value,
+			Unit:  rd.Unit,
+			Time:  now,
+		}
+	}
+	return r.updateStatus(ctx, sensor)
+}
+
+func (r *SensorReconciler) updateStatus(
+	ctx context.Context, sensor *iotv1alpha1.Sensor,
+) (res ctrl.Result, err error) {
+	sensor.Status.ObservedGeneration = sensor.Generation
+	if err := r.Status().Update(ctx, sensor); err != nil {
+		return res, fmt.Errorf("updating Sensor status: %w", err)
+	}
+
+	res.RequeueAfter = sensor.Spec.PollInterval.Duration
+	return
+}
@@ -0,0 +1,188 @@
+package sensors
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+	"github.com/thetechnick/iot-operator/internal/clients"
+	"github.com/thetechnick/iot-operator/internal/clients/httpjsonclient"
+	"github.com/thetechnick/iot-operator/internal/clients/shellyrpcclient"
+	"github.com/thetechnick/iot-operator/internal/clients/shellysensorclient"
+)
+
+const (
+	shellyHT        = "ShellyHT"
+	shellyDW2       = "ShellyDW2"
+	shellyPlusAddOn = "ShellyPlusAddOn"
+	httpJSON        = "HTTPJSON"
+)
+
+// Device independent interface to read sensors.
+type device interface {
+	Read(ctx context.Context) ([]reading, error)
+}
+
+// Device independent sensor reading.
+type reading struct {
+	Name  string
+	Type  iotv1alpha1.SensorReadingType
+	Value string
+	Unit  string
+}
+
+// Returns the device implementation for the given Sensor
+// or nil, if the device type is unknown.
+func newDevice(sensor *iotv1alpha1.Sensor) (device, error) {
+	endpoint := clients.WithEndpoint(sensor.Spec.Endpoint.URL)
+	switch sensor.Spec.DeviceType {
+	case shellyHT, shellyDW2:
+		return &shellySensorDevice{
+			c: shellysensorclient.NewClient(endpoint),
+		}, nil
+	case shellyPlusAddOn:
+		return &shellyPlusAddOnDevice{
+			c:       shellyrpcclient.NewClient(endpoint),
+			channel: sensor.Spec.Channel,
+		}, nil
+	case httpJSON:
+		c, err := httpjsonclient.NewClient(sensor.Spec.Endpoint.URL)
+		if err != nil {
+			return nil, err
+		}
+		return &httpJSONDevice{
+			c:        c,
+			mappings: sensor.Spec.Readings,
+		}, nil
+	}
+	return nil, nil
+}
+
+// List of all supported device types for error reporting.
+var knownDeviceTypes = []string{
+	shellyHT,
+	shellyDW2,
+	shellyPlusAddOn,
+	httpJSON,
+}
+
+// Shelly Gen1 sensors, e.g. Shelly H&T and Door/Window 2.
+type shellySensorDevice struct {
+	c *shellysensorclient.Client
+}
+
+func (d *shellySensorDevice) Read(ctx context.Context) ([]reading, error) {
+	status, err := d.c.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var readings []reading
+	if t := status.Temperature; t != nil && t.IsValid {
+		readings = append(readings, reading{
+			Name:  "temperature",
+			Type:  iotv1alpha1.SensorReadingTypeTemperature,
+			Value: formatFloat(t.Celsius),
+			Unit:  "C",
+		})
+	}
+	if h := status.Humidity; h != nil && h.IsValid {
+		readings = append(readings, reading{
+			Name:  "humidity",
+			Type:  iotv1alpha1.SensorReadingTypeHumidity,
+			Value: formatFloat(h.Value),
+			Unit:  "%",
+		})
+	}
+	if l := status.Lux; l != nil && l.IsValid {
+		readings = append(readings, reading{
+			Name:  "illuminance",
+			Type:  iotv1alpha1.SensorReadingTypeIlluminance,
+			Value: formatFloat(l.Value),
+			Unit:  "lx",
+		})
+	}
+	if s := status.Sensor; s != nil && s.IsValid {
+		readings = append(readings, reading{
+			Name:  "contact",
+			Type:  iotv1alpha1.SensorReadingTypeContact,
+			Value: s.State,
+		})
+	}
+	if b := status.Battery; b != nil {
+		readings = append(readings, reading{
+			Name:  "battery",
+			Type:  iotv1alpha1.SensorReadingTypeBattery,
+			Value: strconv.Itoa(b.Value),
+			Unit:  "%",
+		})
+	}
+	return readings, nil
+}
+
+// Shelly Gen2 sensor peripherals, e.g. connected to a Shelly Plus Add-on.
+type shellyPlusAddOnDevice struct {
+	c       *shellyrpcclient.Client
+	channel int
+}
+
+func (d *shellyPlusAddOnDevice) Read(ctx context.Context) ([]reading, error) {
+	temperature, err := d.c.TemperatureGetStatus(ctx, d.channel)
+	if err != nil {
+		return nil, err
+	}
+
+	var readings []reading
+	if temperature.TC != nil {
+		readings = append(readings, reading{
+			Name:  "temperature",
+			Type:  iotv1alpha1.SensorReadingTypeTemperature,
+			Value: formatFloat(*temperature.TC),
+			Unit:  "C",
+		})
+	}
+
+	// only some sensors like the DHT22 report humidity.
+	if humidity, err := d.c.HumidityGetStatus(ctx, d.channel); err == nil && humidity.RH != nil {
+		readings = append(readings, reading{
+			Name:  "humidity",
+			Type:  iotv1alpha1.SensorReadingTypeHumidity,
+			Value: formatFloat(*humidity.RH),
+			Unit:  "%",
+		})
+	}
+	return readings, nil
+}
+
+// Generic JSON documents served via HTTP.
+type httpJSONDevice struct {
+	c        *httpjsonclient.Client
+	mappings []iotv1alpha1.SensorReadingMapping
+}
+
+func (d *httpJSONDevice) Read(ctx context.Context) ([]reading, error) {
+	doc, err := d.c.Document(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	readings := make([]reading, len(d.mappings))
+	for i, m := range d.mappings {
+		value, err := httpjsonclient.Extract(doc, m.JSONPath)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", m.Name, err)
+		}
+		readings[i] = reading{
+			Name:  m.Name,
+			Type:  m.Type,
+			Value: value,
+			Unit:  m.Unit,
+		}
+	}
+	return readings, nil
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
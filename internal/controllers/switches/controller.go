@@ -0,0 +1,101 @@
+package switches
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+)
+
+type SwitchReconciler struct {
+	client.Client
+	Log                    logr.Logger
+	Scheme                 *runtime.Scheme
+	DefaultRequeueInterval time.Duration
+}
+
+func (r *SwitchReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&iotv1alpha1.Switch{}).
+		Complete(r)
+}
+
+func (r *SwitchReconciler) Reconcile(
+	ctx context.Context, req ctrl.Request) (res ctrl.Result, err error) {
+	log := r.Log.WithValues("switch", req.NamespacedName.String())
+	defer log.Info("reconciled")
+
+	sw := &iotv1alpha1.Switch{}
+	if err := r.Get(ctx, req.NamespacedName, sw); err != nil {
+		return res, client.IgnoreNotFound(err)
+	}
+
+	// Determine Client
+	dt := sw.Spec.DeviceType
+	dev := newDevice(sw)
+	if dev == nil {
+		meta.SetStatusCondition(&sw.Status.Conditions, metav1.Condition{
+			Type:   iotv1alpha1.SwitchReachable,
+			Status: metav1.ConditionFalse,
+			Reason: "UnkownDeviceType",
+			Message: fmt.Sprintf("Unkown device type %q, must be one of: [%s]",
+				dt, strings.Join(knownDeviceTypes, ", ")),
+		})
+		return r.updateStatus(ctx, sw)
+	}
+
+	// Status handling
+	status, err := dev.Status(ctx)
+	if err != nil {
+		return res, fmt.Errorf("reconciling %s: reading status: %w", dt, err)
+	}
+	meta.SetStatusCondition(&sw.Status.Conditions, metav1.Condition{
+		Type:    iotv1alpha1.SwitchReachable,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Connected",
+		Message: "connected to device",
+	})
+
+	// Desired state handling
+	// the relay can also be toggled at the wall switch,
+	// so the state is only sent when the spec changes, instead of on every poll.
+	if len(sw.Spec.State) > 0 && sw.Status.ObservedGeneration != sw.Generation {
+		on := sw.Spec.State == iotv1alpha1.SwitchStateOn
+		if status.On != on {
+			if err := dev.Set(ctx, on); err != nil {
+				return res, fmt.Errorf("reconciling %s: switching: %w", dt, err)
+			}
+			status.On = on
+		}
+	}
+
+	if status.On {
+		sw.Status.State = iotv1alpha1.SwitchStateOn
+	} else {
+		sw.Status.State = iotv1alpha1.SwitchStateOff
+	}
+	sw.Status.Power = int(status.Power)
+	return r.updateStatus(ctx, sw)
+}
+
+func (r *SwitchReconciler) updateStatus(
+	ctx context.Context, sw *iotv1alpha1.Switch,
+) (res ctrl.Result, err error) {
+	sw.Status.ObservedGeneration = sw.Generation
+	if err := r.Status().Update(ctx, sw); err != nil {
+		return res, fmt.Errorf("updating Switch status: %w", err)
+	}
+
+	// always get a new status every now and then
+	res.RequeueAfter = r.DefaultRequeueInterval
+	return
+}
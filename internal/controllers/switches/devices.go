@@ -0,0 +1,145 @@
+package switches
+
+import (
+	"context"
+	"fmt"
+
+	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+	"github.com/thetechnick/iot-operator/internal/clients"
+	"github.com/thetechnick/iot-operator/internal/clients/shellyrelayclient"
+	"github.com/thetechnick/iot-operator/internal/clients/shellyrpcclient"
+	"github.com/thetechnick/iot-operator/internal/clients/tasmotaclient"
+)
+
+const (
+	shellyRelay     = "ShellyRelay"
+	shellyPlusRelay = "ShellyPlusRelay"
+	tasmotaRelay    = "TasmotaRelay"
+)
+
+// Device independent interface to control a switch.
+type device interface {
+	Status(ctx context.Context) (deviceStatus, error)
+	Set(ctx context.Context, on bool) error
+}
+
+// Device independent switch status.
+type deviceStatus struct {
+	On bool
+	// Power consumption in Watts.
+	Power float64
+}
+
+// Returns the device implementation for the given Switch
+// or nil, if the device type is unknown.
+func newDevice(sw *iotv1alpha1.Switch) device {
+	endpoint := clients.WithEndpoint(sw.Spec.Endpoint.URL)
+	switch sw.Spec.DeviceType {
+	case shellyRelay:
+		return &shellyRelayDevice{
+			c:       shellyrelayclient.NewClient(endpoint),
+			channel: sw.Spec.Channel,
+		}
+	case shellyPlusRelay:
+		return &shellyPlusRelayDevice{
+			c:       shellyrpcclient.NewClient(endpoint),
+			channel: sw.Spec.Channel,
+		}
+	case tasmotaRelay:
+		return &tasmotaRelayDevice{
+			c: tasmotaclient.NewClient(endpoint),
+			// Tasmota numbers relays starting at 1.
+			relay: sw.Spec.Channel + 1,
+		}
+	}
+	return nil
+}
+
+// List of all supported device types for error reporting.
+var knownDeviceTypes = []string{
+	shellyRelay,
+	shellyPlusRelay,
+	tasmotaRelay,
+}
+
+// Shelly Gen1 relays, e.g. Shelly 1 and 1PM.
+type shellyRelayDevice struct {
+	c       *shellyrelayclient.Client
+	channel int
+}
+
+func (d *shellyRelayDevice) Status(ctx context.Context) (deviceStatus, error) {
+	status, err := d.c.Status(ctx)
+	if err != nil {
+		return deviceStatus{}, err
+	}
+	if d.channel < 0 || d.channel >= len(status.Relays) {
+		return deviceStatus{}, fmt.Errorf(
+			"channel %d not found, device has %d relays", d.channel, len(status.Relays))
+	}
+
+	s := deviceStatus{
+		On: status.Relays[d.channel].IsOn,
+	}
+	// not all relays have a power meter
+	if d.channel < len(status.Meters) {
+		s.Power = status.Meters[d.channel].Power
+	}
+	return s, nil
+}
+
+func (d *shellyRelayDevice) Set(ctx context.Context, on bool) error {
+	_, err := d.c.Turn(ctx, d.channel, on)
+	return err
+}
+
+// Shelly Gen2 relays, e.g. Shelly Plus 1 and Plus 1PM.
+type shellyPlusRelayDevice struct {
+	c       *shellyrpcclient.Client
+	channel int
+}
+
+func (d *shellyPlusRelayDevice) Status(ctx context.Context) (deviceStatus, error) {
+	status, err := d.c.SwitchGetStatus(ctx, d.channel)
+	if err != nil {
+		return deviceStatus{}, err
+	}
+	return deviceStatus{
+		On:    status.Output,
+		Power: status.APower,
+	}, nil
+}
+
+func (d *shellyPlusRelayDevice) Set(ctx context.Context, on bool) error {
+	_, err := d.c.SwitchSet(ctx, d.channel, on)
+	return err
+}
+
+// Relays on devices running the Tasmota firmware, e.g. Sonoff Basic.
+type tasmotaRelayDevice struct {
+	c     *tasmotaclient.Client
+	relay int
+}
+
+func (d *tasmotaRelayDevice) Status(ctx context.Context) (deviceStatus, error) {
+	on, err := d.c.Power(ctx, d.relay)
+	if err != nil {
+		return deviceStatus{}, err
+	}
+	sensorStatus, err := d.c.SensorStatus(ctx)
+	if err != nil {
+		return deviceStatus{}, err
+	}
+
+	s := deviceStatus{On: on}
+	// not all relays have a power meter
+	if energy := sensorStatus.StatusSNS.Energy; energy != nil {
+		s.Power, _ = energy.RelayPower(d.relay)
+	}
+	return s, nil
+}
+
+func (d *tasmotaRelayDevice) Set(ctx context.Context, on bool) error {
+	_, err := d.c.SetPower(ctx, d.relay, on)
+	return err
+}
@@ -0,0 +1,154 @@
+package thermostats
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+)
+
+type ThermostatReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+func (r *ThermostatReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(
+			&iotv1alpha1.Thermostat{},
+			// measured temperature and valve position change the status on every poll,
+			// only spec changes have to reach the TRV before the next PollInterval.
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Complete(r)
+}
+
+func (r *ThermostatReconciler) Reconcile(
+	ctx context.Context, req ctrl.Request) (res ctrl.Result, err error) {
+	log := r.Log.WithValues("thermostat", req.NamespacedName.String())
+	defer log.Info("reconciled")
+
+	thermostat := &iotv1alpha1.Thermostat{}
+	if err := r.Get(ctx, req.NamespacedName, thermostat); err != nil {
+		return res, client.IgnoreNotFound(err)
+	}
+
+	// Determine Client
+	dt := thermostat.Spec.DeviceType
+	dev := newDevice(thermostat)
+	if dev == nil {
+		meta.SetStatusCondition(&thermostat.Status.Conditions, metav1.Condition{
+			Type:   iotv1alpha1.ThermostatReachable,
+			Status: metav1.ConditionFalse,
+			Reason: "UnkownDeviceType",
+			Message: fmt.Sprintf("Unkown device type %q, must be one of: [%s]",
+				dt, strings.Join(knownDeviceTypes, ", ")),
+		})
+		return r.updateStatus(ctx, thermostat)
+	}
+
+	// Status handling
+	status, err := dev.Status(ctx)
+	if err != nil {
+		// Battery powered devices are asleep most of the time,
+		// keep the last known status and try again later.
+		meta.SetStatusCondition(&thermostat.Status.Conditions, metav1.Condition{
+			Type:    iotv1alpha1.ThermostatReachable,
+			Status:  metav1.ConditionFalse,
+			Reason:  "Unreachable",
+			Message: fmt.Sprintf("device asleep or offline: %v", err),
+		})
+		return r.updateStatus(ctx, thermostat)
+	}
+	meta.SetStatusCondition(&thermostat.Status.Conditions, metav1.Condition{
+		Type:    iotv1alpha1.ThermostatReachable,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Connected",
+		Message: "connected to device",
+	})
+	now := metav1.Now()
+	thermostat.Status.LastSeenTime = &now
+
+	// Desired state handling
+	if thermostat.Status.ObservedGeneration != thermostat.Generation {
+		if err := dev.Apply(ctx, thermostat.Spec); err != nil {
+			return res, fmt.Errorf("reconciling %s: applying settings: %w", dt, err)
+		}
+		thermostat.Status.ObservedGeneration = thermostat.Generation
+
+		// get the status reflecting the new settings
+		status, err = dev.Status(ctx)
+		if err != nil {
+			// the settings are on the device,
+			// don't apply them again, e.g. restarting a Boost.
+			if _, err := r.updateStatus(ctx, thermostat); err != nil {
+				return res, err
+			}
+			return res, fmt.Errorf("reconciling %s: reading status: %w", dt, err)
+		}
+	}
+
+	thermostat.Status.Temperature = nil
+	if status.Temperature != nil {
+		thermostat.Status.Temperature = quantity(*status.Temperature)
+	}
+	thermostat.Status.TargetTemperature = quantity(status.TargetTemperature)
+	thermostat.Status.ValvePosition = status.ValvePosition
+	thermostat.Status.Battery = status.Battery
+	thermostat.Status.ScheduleEnabled = status.ScheduleEnabled
+	thermostat.Status.WindowOpen = status.WindowOpen
+	return r.updateStatus(ctx, thermostat)
+}
+
+// Updates the status.
+// ObservedGeneration is only bumped after the spec was applied to the device.
+func (r *ThermostatReconciler) updateStatus(
+	ctx context.Context, thermostat *iotv1alpha1.Thermostat,
+) (res ctrl.Result, err error) {
+	pending := thermostat.Status.ObservedGeneration != thermostat.Generation
+	if pending {
+		meta.SetStatusCondition(&thermostat.Status.Conditions, metav1.Condition{
+			Type:    iotv1alpha1.ThermostatSynced,
+			Status:  metav1.ConditionFalse,
+			Reason:  "Pending",
+			Message: "waiting for the device to become reachable",
+		})
+	} else {
+		meta.SetStatusCondition(&thermostat.Status.Conditions, metav1.Condition{
+			Type:    iotv1alpha1.ThermostatSynced,
+			Status:  metav1.ConditionTrue,
+			Reason:  "Applied",
+			Message: "settings applied to device",
+		})
+	}
+
+	if err := r.Status().Update(ctx, thermostat); err != nil {
+		return res, fmt.Errorf("updating Thermostat status: %w", err)
+	}
+
+	if pending {
+		// try to catch the device while it's awake
+		res.RequeueAfter = thermostat.Spec.RetryInterval.Duration
+	} else {
+		res.RequeueAfter = thermostat.Spec.PollInterval.Duration
+	}
+	return
+}
+
+// Converts a float into a Quantity with milli precision.
+func quantity(f float64) *resource.Quantity {
+	return resource.NewMilliQuantity(int64(math.Round(f*1000)), resource.DecimalSI)
+}
@@ -0,0 +1,111 @@
+package thermostats
+
+import (
+	"context"
+	"fmt"
+
+	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+	"github.com/thetechnick/iot-operator/internal/clients"
+	"github.com/thetechnick/iot-operator/internal/clients/shellytrvclient"
+)
+
+const shellyTRV = "ShellyTRV"
+
+// Device independent interface to control a thermostat.
+type device interface {
+	Status(ctx context.Context) (deviceStatus, error)
+	Apply(ctx context.Context, spec iotv1alpha1.ThermostatSpec) error
+}
+
+// Device independent thermostat status.
+type deviceStatus struct {
+	// Measured temperature in degrees Celsius, nil if invalid.
+	Temperature *float64
+	// Target temperature in degrees Celsius.
+	TargetTemperature float64
+	// Valve position in percent open.
+	ValvePosition int
+	// Battery level in percent.
+	Battery         int
+	ScheduleEnabled bool
+	WindowOpen      bool
+}
+
+// Returns the device implementation for the given Thermostat
+// or nil, if the device type is unknown.
+func newDevice(thermostat *iotv1alpha1.Thermostat) device {
+	switch thermostat.Spec.DeviceType {
+	case shellyTRV:
+		return &shellyTRVDevice{
+			c: shellytrvclient.NewClient(
+				clients.WithEndpoint(thermostat.Spec.Endpoint.URL)),
+			channel: thermostat.Spec.Channel,
+		}
+	}
+	return nil
+}
+
+// List of all supported device types for error reporting.
+var knownDeviceTypes = []string{
+	shellyTRV,
+}
+
+type shellyTRVDevice struct {
+	c       *shellytrvclient.Client
+	channel int
+}
+
+func (d *shellyTRVDevice) Status(ctx context.Context) (deviceStatus, error) {
+	status, err := d.c.Status(ctx)
+	if err != nil {
+		return deviceStatus{}, err
+	}
+	if d.channel < 0 || d.channel >= len(status.Thermostats) {
+		return deviceStatus{}, fmt.Errorf(
+			"channel %d not found, device has %d thermostats", d.channel, len(status.Thermostats))
+	}
+
+	thermostat := status.Thermostats[d.channel]
+	s := deviceStatus{
+		TargetTemperature: thermostat.Target.Value,
+		ValvePosition:     int(thermostat.Position),
+		Battery:           status.Battery.Value,
+		ScheduleEnabled:   thermostat.Schedule,
+		WindowOpen:        thermostat.WindowOpen,
+	}
+	if thermostat.Temperature.IsValid {
+		s.Temperature = &thermostat.Temperature.Value
+	}
+	return s, nil
+}
+
+func (d *shellyTRVDevice) Apply(
+	ctx context.Context, spec iotv1alpha1.ThermostatSpec,
+) error {
+	schedule := !spec.ScheduleOverride
+	params := shellytrvclient.ThermostatParams{
+		Schedule: &schedule,
+	}
+
+	switch spec.Mode {
+	case iotv1alpha1.ThermostatModeOff:
+		targetEnabled, position := false, 0
+		params.TargetEnabled = &targetEnabled
+		params.Position = &position
+
+	case iotv1alpha1.ThermostatModeBoost:
+		boostMinutes := int(spec.BoostDuration.Minutes())
+		params.BoostMinutes = &boostMinutes
+
+	default:
+		targetEnabled := true
+		params.TargetEnabled = &targetEnabled
+		if spec.TargetTemperature != nil {
+			target := spec.TargetTemperature.AsApproximateFloat64()
+			params.Target = &target
+		}
+	}
+
+	_, err := d.c.SetThermostat(ctx, d.channel, params)
+	return err
+}
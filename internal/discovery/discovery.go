@@ -0,0 +1,200 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/hashicorp/mdns"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+	"github.com/thetechnick/iot-operator/internal/identity"
+)
+
+// mDNS services to browse.
+var services = []string{
+	"_shelly._tcp",
+	"_http._tcp",
+}
+
+const (
+	browseTimeout   = 5 * time.Second
+	identityTimeout = 5 * time.Second
+)
+
+// Discovery browses the network via mDNS and
+// records identified devices as DiscoveredDevice objects.
+// Needs to run in the host network to receive mDNS responses.
+type Discovery struct {
+	Client client.Client
+	Log    logr.Logger
+	// Namespace to create DiscoveredDevices in.
+	Namespace string
+	// Interval between network scans.
+	Interval time.Duration
+}
+
+// Start implements manager.Runnable.
+func (d *Discovery) Start(ctx context.Context) error {
+	ticker := time.NewTicker(d.Interval)
+	defer ticker.Stop()
+
+	for {
+		d.discover(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *Discovery) discover(ctx context.Context) {
+	for _, address := range d.browse() {
+		log := d.Log.WithValues("address", address)
+
+		identityCtx, cancel := context.WithTimeout(ctx, identityTimeout)
+		id, err := identity.Query(identityCtx, address)
+		cancel()
+		if err != nil {
+			// lots of things announce _http._tcp
+			log.V(1).Info("skipping unidentified device", "error", err.Error())
+			continue
+		}
+		if len(objectName(id.DeviceID)) == 0 {
+			// nothing to name the DiscoveredDevice after
+			log.V(1).Info("skipping device without ID", "model", id.Model)
+			continue
+		}
+
+		if err := d.record(ctx, address, id); err != nil {
+			log.Error(err, "recording DiscoveredDevice")
+		}
+	}
+}
+
+// Returns the endpoint URLs of all devices announcing one of the services.
+func (d *Discovery) browse() []string {
+	var (
+		mux       sync.Mutex
+		addresses = map[string]struct{}{}
+		wg        sync.WaitGroup
+	)
+	for _, service := range services {
+		entries := make(chan *mdns.ServiceEntry, 32)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for entry := range entries {
+				if entry.AddrV4 == nil {
+					continue
+				}
+				address := "http://" + net.JoinHostPort(
+					entry.AddrV4.String(), strconv.Itoa(entry.Port)) + "/"
+				mux.Lock()
+				addresses[address] = struct{}{}
+				mux.Unlock()
+			}
+		}()
+
+		params := mdns.DefaultParams(service)
+		params.Entries = entries
+		params.Timeout = browseTimeout
+		params.DisableIPv6 = true
+		if err := mdns.Query(params); err != nil {
+			d.Log.Error(err, "browsing mDNS", "service", service)
+		}
+		close(entries)
+	}
+	wg.Wait()
+
+	list := make([]string, 0, len(addresses))
+	for address := range addresses {
+		list = append(list, address)
+	}
+	return list
+}
+
+// Creates or updates the DiscoveredDevice for the identified device.
+func (d *Discovery) record(
+	ctx context.Context, address string, id identity.Identity,
+) error {
+	device := &iotv1alpha1.DiscoveredDevice{}
+	key := client.ObjectKey{
+		Name:      objectName(id.DeviceID),
+		Namespace: d.Namespace,
+	}
+	err := d.Client.Get(ctx, key, device)
+	if errors.IsNotFound(err) {
+		device.Name = key.Name
+		device.Namespace = key.Namespace
+		if err := d.Client.Create(ctx, device); err != nil {
+			return fmt.Errorf("creating DiscoveredDevice: %w", err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("getting DiscoveredDevice: %w", err)
+	}
+
+	ApplyIdentity(&device.Status, address, id)
+	if err := d.Client.Status().Update(ctx, device); err != nil {
+		return fmt.Errorf("updating DiscoveredDevice status: %w", err)
+	}
+	return nil
+}
+
+// Records the identity of the device reachable at address
+// and suggests objects to control it with.
+func ApplyIdentity(
+	status *iotv1alpha1.DiscoveredDeviceStatus,
+	address string, id identity.Identity,
+) {
+	now := metav1.Now()
+	status.Address = address
+	status.DeviceID = id.DeviceID
+	status.Model = id.Model
+	status.MAC = id.MAC
+	status.Firmware = id.Firmware
+	status.LastSeenTime = &now
+
+	status.SuggestedRollerShutter = nil
+	status.SuggestedSwitch = nil
+	status.SuggestedLight = nil
+	switch id.Kind {
+	case identity.KindRollerShutter:
+		status.SuggestedRollerShutter = &iotv1alpha1.RollerShutterSpec{
+			DeviceType: id.DeviceType,
+			Endpoint: iotv1alpha1.RollerShutterEndpoint{
+				URL:      address,
+				DeviceID: id.DeviceID,
+			},
+		}
+	case identity.KindSwitch:
+		status.SuggestedSwitch = &iotv1alpha1.SwitchSpec{
+			DeviceType: id.DeviceType,
+			Endpoint:   iotv1alpha1.DeviceEndpoint{URL: address},
+		}
+	case identity.KindLight:
+		status.SuggestedLight = &iotv1alpha1.LightSpec{
+			DeviceType: id.DeviceType,
+			Endpoint:   iotv1alpha1.DeviceEndpoint{URL: address},
+		}
+	}
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// Returns a valid object name for the device ID.
+func objectName(deviceID string) string {
+	name := invalidNameChars.ReplaceAllString(strings.ToLower(deviceID), "-")
+	return strings.Trim(name, "-")
+}
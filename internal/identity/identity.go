@@ -0,0 +1,156 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/thetechnick/iot-operator/internal/clients"
+	"github.com/thetechnick/iot-operator/internal/clients/shellyinfoclient"
+	"github.com/thetechnick/iot-operator/internal/clients/tasmotaclient"
+)
+
+// Identity of a device, as reported by the device itself.
+type Identity struct {
+	// Device ID, e.g. shellyplus2pm-a8032ab12345.
+	DeviceID string
+	// Model or device type, e.g. SHSW-25.
+	Model string
+	// MAC address, upper case without separators.
+	MAC      string
+	Firmware string
+	// Kind of object to control the device with,
+	// empty if the device is not supported.
+	Kind Kind
+	// Device type to control the device with,
+	// see devices.go of the respective controller.
+	DeviceType string
+}
+
+type Kind string
+
+const (
+	KindRollerShutter Kind = "RollerShutter"
+	KindSwitch        Kind = "Switch"
+	KindLight         Kind = "Light"
+)
+
+// Queries the identity of the device at the given endpoint URL.
+// Shelly devices are tried first, then Tasmota.
+func Query(ctx context.Context, endpoint string) (Identity, error) {
+	shellyID, shellyErr := queryShelly(ctx, endpoint)
+	if shellyErr == nil {
+		return shellyID, nil
+	}
+	tasmotaID, tasmotaErr := queryTasmota(ctx, endpoint)
+	if tasmotaErr == nil {
+		return tasmotaID, nil
+	}
+	return Identity{}, fmt.Errorf(
+		"unknown device: shelly: %v, tasmota: %v", shellyErr, tasmotaErr)
+}
+
+func queryShelly(ctx context.Context, endpoint string) (Identity, error) {
+	c := shellyinfoclient.NewClient(clients.WithEndpoint(endpoint))
+	info, err := c.Info(ctx)
+	if err != nil {
+		return Identity{}, err
+	}
+	mac := NormalizeMAC(info.MAC)
+	if len(mac) != 12 {
+		return Identity{}, fmt.Errorf("invalid MAC address %q", info.MAC)
+	}
+
+	if info.Gen >= 2 {
+		id := Identity{
+			DeviceID: info.ID,
+			Model:    info.Model,
+			MAC:      mac,
+			Firmware: info.Ver,
+		}
+		switch {
+		case info.Profile == "cover":
+			id.Kind, id.DeviceType = KindRollerShutter, "ShellyPlusCover"
+		case hasPrefix(info.Model, gen2RelayModels):
+			id.Kind, id.DeviceType = KindSwitch, "ShellyPlusRelay"
+		}
+		return id, nil
+	}
+
+	// Gen1 devices only report their ID as hostname in /settings,
+	// it uses a model name that differs from the reported type,
+	// e.g. shellyswitch25-C45BBE for SHSW-25.
+	settings, err := c.Settings(ctx)
+	if err != nil {
+		return Identity{}, fmt.Errorf("getting settings: %w", err)
+	}
+	id := Identity{
+		DeviceID: settings.Device.Hostname,
+		Model:    info.Type,
+		MAC:      mac,
+		Firmware: info.FW,
+	}
+	switch {
+	case info.NumRollers > 0 && info.Mode == "roller":
+		id.Kind, id.DeviceType = KindRollerShutter, "Shelly25Roller"
+	case info.Type == "SHRGBW2":
+		id.Kind, id.DeviceType = KindLight, "ShellyRGBW2"
+	case info.Type == "SHDM-1" || info.Type == "SHDM-2" || info.Type == "SHBDUO-1":
+		id.Kind, id.DeviceType = KindLight, "ShellyDimmer"
+	case info.NumOutputs > 0:
+		id.Kind, id.DeviceType = KindSwitch, "ShellyRelay"
+	}
+	return id, nil
+}
+
+func queryTasmota(ctx context.Context, endpoint string) (Identity, error) {
+	c := tasmotaclient.NewClient(clients.WithEndpoint(endpoint))
+	status, err := c.Status(ctx)
+	if err != nil {
+		return Identity{}, err
+	}
+	if len(status.StatusNET.Mac) == 0 {
+		return Identity{}, fmt.Errorf("no MAC address reported")
+	}
+
+	id := Identity{
+		DeviceID: status.StatusNET.Hostname,
+		Model:    status.StatusFWR.Hardware,
+		MAC:      NormalizeMAC(status.StatusNET.Mac),
+		Firmware: status.StatusFWR.Version,
+	}
+	shutters, err := c.ShutterSettings(ctx)
+	if err == nil && len(shutters.StatusSHT) > 0 {
+		id.Kind, id.DeviceType = KindRollerShutter, "TasmotaShutter"
+		return id, nil
+	}
+	// sensor-only devices have no relays
+	relays, err := c.RelayCount(ctx)
+	if err == nil && relays > 0 {
+		id.Kind, id.DeviceType = KindSwitch, "TasmotaRelay"
+	}
+	return id, nil
+}
+
+// Model ID prefixes of Gen2 devices with relay outputs,
+// e.g. SNSW-001X16EU for the Shelly Plus 1.
+// Other devices like the Plus H&T, Plus i4 or Pro 3EM can't be controlled.
+var gen2RelayModels = []string{
+	"SNSW-", // Plus 1, Plus 1PM, Plus 2PM
+	"SPSW-", // Pro 1, Pro 1PM, Pro 2, Pro 2PM, Pro 4PM
+	"SNPL-", // Plus Plug
+}
+
+func hasPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the MAC address in upper case without separators.
+func NormalizeMAC(mac string) string {
+	return strings.ToUpper(strings.NewReplacer(":", "", "-", "", ".", "").Replace(mac))
+}
@@ -0,0 +1,100 @@
+package sensors
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+	"github.com/thetechnick/iot-operator/internal/clients/httpjsonclient"
+)
+
+// Readings of Sensor objects older than this many poll intervals
+// are considered stale, if the SensorReference sets no max age.
+const maxReadingAgeIntervals = 3
+
+// Reads the current value of the given SensorSource.
+// Sensor objects are looked up in the given namespace.
+func Read(
+	ctx context.Context, c client.Reader,
+	namespace string, source iotv1alpha1.SensorSource,
+) (string, error) {
+	if source.SensorRef != nil {
+		return readSensorRef(ctx, c, namespace, *source.SensorRef)
+	}
+
+	jc, err := httpjsonclient.NewClient(source.URL)
+	if err != nil {
+		return "", err
+	}
+
+	value, err := jc.Read(ctx, source.JSONPath)
+	if err != nil {
+		return "", fmt.Errorf("reading sensor %s: %w", source.URL, err)
+	}
+	return value, nil
+}
+
+func readSensorRef(
+	ctx context.Context, c client.Reader,
+	namespace string, ref iotv1alpha1.SensorReference,
+) (string, error) {
+	sensor := &iotv1alpha1.Sensor{}
+	if err := c.Get(ctx, client.ObjectKey{
+		Name:      ref.Name,
+		Namespace: namespace,
+	}, sensor); err != nil {
+		return "", fmt.Errorf("getting Sensor %s: %w", ref.Name, err)
+	}
+
+	// Sensors keep their last readings while the device is asleep or offline,
+	// so only the age of a reading tells whether it can still be trusted.
+	maxAge := sensor.Spec.PollInterval.Duration * maxReadingAgeIntervals
+	if ref.MaxAge != nil {
+		maxAge = ref.MaxAge.Duration
+	}
+	for _, reading := range sensor.Status.Readings {
+		if reading.Name != ref.Reading {
+			continue
+		}
+		if maxAge > 0 && time.Since(reading.Time.Time) > maxAge {
+			return "", fmt.Errorf("reading %q of Sensor %s is older than %s",
+				ref.Reading, ref.Name, maxAge)
+		}
+		return reading.Value, nil
+	}
+	return "", fmt.Errorf("no reading %q in Sensor %s", ref.Reading, ref.Name)
+}
+
+// Parses a sensor reading into a Quantity.
+// Boolean readings are interpreted as 1 (true) and 0 (false).
+func ParseQuantity(value string) (resource.Quantity, error) {
+	switch strings.ToLower(value) {
+	case "true":
+		return resource.MustParse("1"), nil
+	case "false":
+		return resource.MustParse("0"), nil
+	}
+
+	q, err := resource.ParseQuantity(value)
+	if err != nil {
+		return q, fmt.Errorf("parsing sensor reading %q: %w", value, err)
+	}
+	return q, nil
+}
+
+// Parses a boolean sensor reading.
+// Contact states like open/close and on/off are supported.
+func ParseBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "true", "1", "open", "on":
+		return true, nil
+	case "false", "0", "close", "closed", "off":
+		return false, nil
+	}
+	return false, fmt.Errorf("parsing sensor reading %q: not a boolean", value)
+}
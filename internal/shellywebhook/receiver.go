@@ -0,0 +1,107 @@
+package shellywebhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	iotv1alpha1 "github.com/thetechnick/iot-operator/apis/iot/v1alpha1"
+)
+
+// Path that Shelly actions have to call, with the device ID as id parameter.
+// The receiver is exposed to the LAN via the NodePort of the iot-operator-webhook Service,
+// e.g. http://<node address>:30082/shelly?id=shellyswitch25-C45BBE
+const Path = "/shelly"
+
+// Receiver accepts HTTP callbacks configured as actions on Shelly devices
+// and turns them into events for the matching RollerShutters.
+type Receiver struct {
+	client client.Reader
+	log    logr.Logger
+	addr   string
+	events chan event.GenericEvent
+}
+
+func NewReceiver(c client.Reader, log logr.Logger, addr string) *Receiver {
+	return &Receiver{
+		client: c,
+		log:    log,
+		addr:   addr,
+		events: make(chan event.GenericEvent),
+	}
+}
+
+// Events for RollerShutters whose device reported a state change.
+// To be used with source.Channel.
+func (r *Receiver) Events() <-chan event.GenericEvent {
+	return r.events
+}
+
+// Start implements manager.Runnable and serves callbacks until ctx is done.
+func (r *Receiver) Start(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.Handle(Path, r)
+	server := &http.Server{
+		Addr:    r.addr,
+		Handler: mux,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			r.log.Error(err, "shutting down webhook receiver")
+		}
+	}()
+
+	r.log.Info("starting webhook receiver", "addr", r.addr)
+	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	// Source addresses are rewritten when forwarding traffic to the Pod,
+	// so only the device ID identifies the device.
+	deviceID := req.URL.Query().Get("id")
+	if len(deviceID) == 0 {
+		http.Error(w, "missing id parameter", http.StatusBadRequest)
+		return
+	}
+
+	rollerShutterList := &iotv1alpha1.RollerShutterList{}
+	if err := r.client.List(req.Context(), rollerShutterList); err != nil {
+		r.log.Error(err, "listing RollerShutters")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	var matched int
+	for i := range rollerShutterList.Items {
+		rollerShutter := &rollerShutterList.Items[i]
+		if rollerShutter.Spec.Endpoint.DeviceID != deviceID {
+			continue
+		}
+
+		select {
+		case r.events <- event.GenericEvent{Object: rollerShutter}:
+			matched++
+		case <-req.Context().Done():
+			return
+		}
+	}
+
+	if matched == 0 {
+		r.log.Info("callback matches no RollerShutter", "id", deviceID)
+		http.Error(w, "no matching device", http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -146,6 +146,7 @@ func (Build) cmd(cmd, goos, goarch string) error {
 func (Build) All() {
 	mg.Deps(
 		mg.F(Build.cmd, "iot-operator-manager", "linux", "amd64"),
+		mg.F(Build.cmd, "iot-history", "", ""),
 		mg.F(Build.cmd, "mage", "", ""),
 	)
 }